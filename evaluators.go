@@ -2,6 +2,7 @@ package gao
 
 import (
 	"errors"
+	"math/rand"
 	"sync"
 
 	"github.com/jonathanmweiss/go-gao/field"
@@ -22,12 +23,49 @@ type EvaluationMap interface {
 	// L(x) = (x - x_1)(x - x_2)...(x - x_n)
 	GenerateLocatorPolynomial(n int) *field.Polynomial
 
+	// Domain returns EvaluationPoints(n) and their monic locator \prod (x - x_i) together, computed once and cached,
+	// or an error where EvaluationPoints would panic (e.g., n unsupported by the evaluator).
+	// Both are shared by later calls, thus must not be modified.
+	Domain(n int) (points []uint64, locator *field.Polynomial, err error)
+
 	isNTT() bool
 }
 
 type evaluationCache struct {
 	sync.Locker
-	degreeToPoints map[int][]uint64
+	degreeToPoints  map[int][]uint64
+	degreeToLocator map[int]*field.Polynomial
+}
+
+// domain returns the cached domain of size n, or computes it with build and caches it.
+func (e *evaluationCache) domain(n int, build func(n int) ([]uint64, *field.Polynomial, error)) ([]uint64, *field.Polynomial, error) {
+	e.Lock()
+	locator, ok := e.degreeToLocator[n]
+	points := e.degreeToPoints[n]
+	e.Unlock()
+
+	if ok {
+		return points, locator, nil
+	}
+
+	points, locator, err := build(n)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	e.storePoints(n, points)
+
+	e.Lock()
+	defer e.Unlock()
+
+	// another goroutine may have won the race; keep the first one.
+	if existing, ok := e.degreeToLocator[n]; ok {
+		return e.degreeToPoints[n], existing, nil
+	}
+
+	e.degreeToLocator[n] = locator
+
+	return e.degreeToPoints[n], locator, nil
 }
 
 func (e evaluationCache) storePoints(n int, points []uint64) {
@@ -41,11 +79,40 @@ func (e evaluationCache) storePoints(n int, points []uint64) {
 	e.degreeToPoints[n] = points
 }
 
+// PointStrategy determines how SlowEvaluator picks its evaluation points.
+// Every strategy yields distinct non-zero field elements, thus supports up to p-1 points.
+type PointStrategy int
+
+const (
+	// x_i = i+1.
+	SequentialPoints PointStrategy = iota
+	// x_i = g^i, where g is the field's generator.
+	GeneratorPowerPoints
+	// x_i are sampled uniformly from a PRNG seeded by a caller-provided seed.
+	SeededRandomPoints
+)
+
+func (s PointStrategy) String() string {
+	switch s {
+	case SequentialPoints:
+		return "sequential"
+	case GeneratorPowerPoints:
+		return "generator-powers"
+	case SeededRandomPoints:
+		return "seeded-random"
+	default:
+		return "unknown"
+	}
+}
+
 // Not Tested. but can support non powers of 2 for $n$ param.
 type SlowEvaluator struct {
 	cache *evaluationCache
 
 	pr field.PolyRing
+
+	strategy PointStrategy
+	seed     int64
 }
 
 func (e *evaluationCache) loadPoints(n int) []uint64 {
@@ -60,16 +127,37 @@ func (e *evaluationCache) loadPoints(n int) []uint64 {
 }
 
 func NewSlowEvaluator(f field.Field) *SlowEvaluator {
+	return NewSlowEvaluatorWithStrategy(f, SequentialPoints)
+}
+
+// NewSlowEvaluatorWithStrategy returns a SlowEvaluator picking its points according to strategy.
+// SeededRandomPoints uses seed 0, see NewSeededSlowEvaluator.
+func NewSlowEvaluatorWithStrategy(f field.Field, strategy PointStrategy) *SlowEvaluator {
 	return &SlowEvaluator{
-		pr:    field.NewDensePolyRing(f),
-		cache: newEvaluatorCache(),
+		pr:       field.NewDensePolyRing(f),
+		cache:    newEvaluatorCache(),
+		strategy: strategy,
 	}
 }
 
+// NewSeededSlowEvaluator returns a SlowEvaluator with random points drawn from a PRNG seeded by seed.
+// Evaluators with the same field and seed produce identical points.
+func NewSeededSlowEvaluator(f field.Field, seed int64) *SlowEvaluator {
+	e := NewSlowEvaluatorWithStrategy(f, SeededRandomPoints)
+	e.seed = seed
+
+	return e
+}
+
+func (e *SlowEvaluator) Strategy() PointStrategy {
+	return e.strategy
+}
+
 func newEvaluatorCache() *evaluationCache {
 	return &evaluationCache{
-		Locker:         &sync.Mutex{},
-		degreeToPoints: make(map[int][]uint64),
+		Locker:          &sync.Mutex{},
+		degreeToPoints:  make(map[int][]uint64),
+		degreeToLocator: make(map[int]*field.Polynomial),
 	}
 }
 
@@ -79,36 +167,76 @@ func (e *SlowEvaluator) EvaluationPoints(n int) []uint64 {
 		return points
 	}
 
-	points = make([]uint64, n)
-	for i := range points {
-		points[i] = uint64(i + 1)
-	}
+	points = e.generatePoints(n)
 
 	e.cache.storePoints(n, points)
 
 	return points
 }
 
+var errTooManyEvaluationPoints = errors.New("number of evaluation points must be smaller than the field's modulus")
+
+func (e *SlowEvaluator) generatePoints(n int) []uint64 {
+	f := e.pr.GetField()
+	if uint64(n) >= f.Modulus() {
+		panic(errTooManyEvaluationPoints)
+	}
+
+	points := make([]uint64, n)
+
+	switch e.strategy {
+	case SequentialPoints:
+		for i := range points {
+			points[i] = uint64(i + 1)
+		}
+
+	case GeneratorPowerPoints:
+		// g generates the multiplicative group, so g^0, ..., g^(p-2) are distinct.
+		x := uint64(1)
+		for i := range points {
+			points[i] = x
+			x = f.Mul(x, f.Generator())
+		}
+
+	case SeededRandomPoints:
+		rnd := rand.New(rand.NewSource(e.seed))
+		seen := make(map[uint64]struct{}, n)
+
+		for i := 0; i < n; {
+			x := rnd.Uint64()%(f.Modulus()-1) + 1 // non-zero.
+			if _, ok := seen[x]; ok {
+				continue
+			}
+
+			seen[x] = struct{}{}
+			points[i] = x
+			i++
+		}
+
+	default:
+		panic("unknown point strategy")
+	}
+
+	return points
+}
+
+func (e *SlowEvaluator) polyRing() field.PolyRing {
+	return e.pr
+}
+
 var errNotInCoefficientForm = errors.New("polynomial not in coefficient form")
 
 func (e *SlowEvaluator) PrimeField() field.Field {
 	return e.pr.GetField()
 }
 
+// EvaluatePolynomial evaluates p over EvaluationPoints(len(p)), sweeping by forward differences over SequentialPoints.
 func (e *SlowEvaluator) EvaluatePolynomial(p *field.Polynomial) ([]uint64, error) {
-	if p.IsCoeffMode() {
+	if p.IsNTT() {
 		return nil, errNotInCoefficientForm
 	}
 
-	points := e.EvaluationPoints(len(p.ToSlice()))
-	values := make([]uint64, len(points))
-
-	pr := e.pr
-	for i, x := range points {
-		values[i] = pr.Evaluate(p, x)
-	}
-
-	return values, nil
+	return e.pr.EvaluateDomain(p, e.EvaluationPoints(len(p.NoCopySlice())))
 }
 
 func (e *SlowEvaluator) GenerateLocatorPolynomial(n int) *field.Polynomial {
@@ -125,10 +253,251 @@ func (e *SlowEvaluator) GenerateLocatorPolynomial(n int) *field.Polynomial {
 		polys[i] = field.NewPolynomial(f, coeffs, false)
 	}
 
-	return field.PolyProduct(e.pr, polys)
+	return field.PolyProductTree(e.pr, polys)
+}
+
+// Domain returns errTooManyEvaluationPoints rather than panicking when n isn't smaller than the field's modulus.
+func (e *SlowEvaluator) Domain(n int) ([]uint64, *field.Polynomial, error) {
+	return e.cache.domain(n, func(n int) ([]uint64, *field.Polynomial, error) {
+		if uint64(n) >= e.pr.GetField().Modulus() {
+			return nil, nil, errTooManyEvaluationPoints
+		}
+
+		return e.EvaluationPoints(n), e.GenerateLocatorPolynomial(n), nil
+	})
 }
 
 // does not support fast Gao.
 func (e *SlowEvaluator) isNTT() bool {
 	return false
 }
+
+// from this n on, NttEvaluator decodes faster than SlowEvaluator: 128µs vs 164µs at n = 32, but 54µs vs 48µs
+// at n = 16 (see BenchmarkAutoNttThreshold).
+const autoNttThreshold = 32
+
+// AutoEvaluator delegates to an NttEvaluator when the field has roots of unity of order n
+// (which also requires n to be a power of two, as only a full subgroup decodes on the NTT path)
+// and n is large enough for NTT to pay off, otherwise it delegates to a SlowEvaluator.
+type AutoEvaluator struct {
+	EvaluationMap
+}
+
+func NewAutoEvaluator(f field.Field, n int) *AutoEvaluator {
+	if n >= autoNttThreshold {
+		if _, err := f.GetRootOfUnity(uint64(n)); err == nil {
+			return &AutoEvaluator{EvaluationMap: NewNttEvaluator(f)}
+		}
+	}
+
+	return &AutoEvaluator{EvaluationMap: NewSlowEvaluator(f)}
+}
+
+// UsesNTT reports whether the NTT evaluator was selected.
+func (e *AutoEvaluator) UsesNTT() bool {
+	return e.EvaluationMap.isNTT()
+}
+
+func (e *AutoEvaluator) polyRing() field.PolyRing {
+	if rm, ok := e.EvaluationMap.(polyRingMap); ok {
+		return rm.polyRing()
+	}
+
+	return field.NewDensePolyRing(e.PrimeField())
+}
+
+func (e *AutoEvaluator) sparseLocatorPolynomial(n int) *field.SparsePolynomial {
+	if sl, ok := e.EvaluationMap.(sparseLocatorMap); ok {
+		return sl.sparseLocatorPolynomial(n)
+	}
+
+	return nil
+}
+
+// NewRandomEvaluator returns an EvaluationMap for codes of length n over a domain drawn from a PRNG seeded by seed:
+// a coset of the roots of unity with a random shift when the field has roots of unity of order n, other than the
+// whole multiplicative group (see CosetNttEvaluator), otherwise n random points (see NewSeededSlowEvaluator).
+// Shifts inside the subgroup are redrawn from the same PRNG, thus NewCodeParameters accepts the coset.
+// Evaluators with the same field, n and seed produce identical evaluation points and locator polynomials,
+// so encoders and decoders on different machines agree on the domain by sharing the seed.
+func NewRandomEvaluator(f field.Field, n int, seed int64) EvaluationMap {
+	if _, err := f.GetRootOfUnity(uint64(n)); err == nil && uint64(n) < f.Modulus()-1 {
+		rnd := rand.New(rand.NewSource(seed))
+
+		for {
+			shift := rnd.Uint64()%(f.Modulus()-1) + 1 // non-zero.
+
+			ev, err := NewCosetNttEvaluator(f, shift)
+			if err != nil {
+				break
+			}
+
+			if ev.validateDomain(n) == nil {
+				return ev
+			}
+		}
+	}
+
+	return NewSeededSlowEvaluator(f, seed)
+}
+
+var ErrUnknownPuncturedPoint = errors.New("punctured point is not an evaluation point of the base evaluator")
+
+// PuncturedEvaluator punctures the codes of a base EvaluationMap, permanently removing some evaluation points:
+// a punctured code of length n evaluates over the base domain of n+len(drop) points, without the dropped ones.
+// n+len(drop) must be a valid length for the base, and the base's points must include the dropped ones:
+// NewCodeParameters returns an error otherwise.
+// The remaining points aren't an NTT domain, so punctured codes always decode on the generic path.
+type PuncturedEvaluator struct {
+	cache *evaluationCache
+
+	base    EvaluationMap
+	pr      field.PolyRing
+	drop    []uint64
+	dropped map[uint64]bool
+}
+
+// NewPuncturedEvaluator returns base without the points in drop. Duplicates in drop are ignored.
+func NewPuncturedEvaluator(base EvaluationMap, drop []uint64) *PuncturedEvaluator {
+	var pr field.PolyRing
+	if rm, ok := base.(polyRingMap); ok {
+		pr = rm.polyRing()
+	} else {
+		pr = field.NewDensePolyRing(base.PrimeField())
+	}
+
+	e := &PuncturedEvaluator{
+		cache:   newEvaluatorCache(),
+		base:    base,
+		pr:      pr,
+		dropped: make(map[uint64]bool, len(drop)),
+	}
+
+	for _, x := range drop {
+		if !e.dropped[x] {
+			e.dropped[x] = true
+			e.drop = append(e.drop, x)
+		}
+	}
+
+	return e
+}
+
+func (e *PuncturedEvaluator) PrimeField() field.Field {
+	return e.base.PrimeField()
+}
+
+func (e *PuncturedEvaluator) polyRing() field.PolyRing {
+	return e.pr
+}
+
+// EvaluationPoints returns the base's points for n+len(drop), in their order, without the dropped ones.
+// Returns nil if a dropped point isn't among them, see validateDomain.
+func (e *PuncturedEvaluator) EvaluationPoints(n int) []uint64 {
+	points := e.cache.loadPoints(n)
+	if points != nil {
+		return points
+	}
+
+	points = e.puncture(e.base.EvaluationPoints(n + len(e.drop)))
+	if len(points) != n {
+		return nil
+	}
+
+	e.cache.storePoints(n, points)
+
+	return points
+}
+
+// puncture returns basePoints without the dropped ones.
+func (e *PuncturedEvaluator) puncture(basePoints []uint64) []uint64 {
+	points := make([]uint64, 0, len(basePoints))
+	for _, x := range basePoints {
+		if !e.dropped[x] {
+			points = append(points, x)
+		}
+	}
+
+	return points
+}
+
+// validateDomain reports why codes of length n can't be punctured from the base, if at all:
+// n+len(drop) must be a valid length for the base, and its points must include the dropped ones.
+func (e *PuncturedEvaluator) validateDomain(n int) error {
+	if _, err := NewCodeParameters(e.base, n+len(e.drop), 0); err != nil {
+		return err
+	}
+
+	if len(e.puncture(e.base.EvaluationPoints(n+len(e.drop)))) != n {
+		return ErrUnknownPuncturedPoint
+	}
+
+	return nil
+}
+
+// EvaluatePolynomial evaluates p over EvaluationPoints(len(p)).
+func (e *PuncturedEvaluator) EvaluatePolynomial(p *field.Polynomial) ([]uint64, error) {
+	if p.IsNTT() {
+		return nil, errNotInCoefficientForm
+	}
+
+	n := len(p.NoCopySlice())
+
+	padded := make([]uint64, n+len(e.drop))
+	copy(padded, p.NoCopySlice())
+
+	ys, err := e.base.EvaluatePolynomial(field.NewPolynomial(e.PrimeField(), padded, false))
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]uint64, 0, n)
+	for i, x := range e.base.EvaluationPoints(len(ys)) {
+		if !e.dropped[x] {
+			values = append(values, ys[i])
+		}
+	}
+
+	return values, nil
+}
+
+// GenerateLocatorPolynomial divides the base's locator by \prod (x - x_d) over the dropped points x_d.
+func (e *PuncturedEvaluator) GenerateLocatorPolynomial(n int) *field.Polynomial {
+	g0 := e.base.GenerateLocatorPolynomial(n + len(e.drop))
+	if len(e.drop) == 0 {
+		return g0
+	}
+
+	locator, _ := e.pr.LongDiv(g0, field.VanishingPolynomial(e.pr, e.drop))
+	e.pr.Normalize(locator, locator)
+
+	return locator
+}
+
+// Domain punctures the base's domain of n+len(drop) points: its locator is divided by \prod (x - x_d).
+func (e *PuncturedEvaluator) Domain(n int) ([]uint64, *field.Polynomial, error) {
+	return e.cache.domain(n, func(n int) ([]uint64, *field.Polynomial, error) {
+		basePoints, g0, err := e.base.Domain(n + len(e.drop))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		points := e.puncture(basePoints)
+		if len(points) != n {
+			return nil, nil, ErrUnknownPuncturedPoint
+		}
+
+		if len(e.drop) == 0 {
+			return points, g0, nil
+		}
+
+		locator, _ := e.pr.LongDiv(g0, field.VanishingPolynomial(e.pr, e.drop))
+
+		return points, locator, nil
+	})
+}
+
+// does not support fast Gao.
+func (e *PuncturedEvaluator) isNTT() bool {
+	return false
+}
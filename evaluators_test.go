@@ -0,0 +1,515 @@
+package gao
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jonathanmweiss/go-gao/field"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPointStrategies(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(157)
+	a.NoError(err)
+
+	evaluators := []*SlowEvaluator{
+		NewSlowEvaluator(f),
+		NewSlowEvaluatorWithStrategy(f, GeneratorPowerPoints),
+		NewSeededSlowEvaluator(f, 42),
+	}
+
+	a.Equal(SequentialPoints, evaluators[0].Strategy())
+	a.Equal(GeneratorPowerPoints, evaluators[1].Strategy())
+	a.Equal(SeededRandomPoints, evaluators[2].Strategy())
+
+	for _, ev := range evaluators {
+		// n approaching p: all non-zero elements are used.
+		for _, n := range []int{1, 10, 155, 156} {
+			points := ev.EvaluationPoints(n)
+			a.Len(points, n)
+
+			seen := make(map[uint64]struct{}, n)
+			for _, x := range points {
+				a.NotZero(x, ev.Strategy().String())
+				a.Less(x, f.Modulus())
+				seen[x] = struct{}{}
+			}
+
+			a.Len(seen, n, "collision in %v points", ev.Strategy())
+		}
+
+		a.Panics(func() { ev.EvaluationPoints(157) })
+	}
+
+	// generator powers.
+	g := f.Generator()
+	a.Equal([]uint64{1, g, f.Mul(g, g)}, evaluators[1].EvaluationPoints(3))
+
+	// same seed, same points.
+	a.Equal(evaluators[2].EvaluationPoints(20), NewSeededSlowEvaluator(f, 42).EvaluationPoints(20))
+	a.NotEqual(evaluators[2].EvaluationPoints(20), NewSeededSlowEvaluator(f, 43).EvaluationPoints(20))
+}
+
+func TestPointStrategiesDecode(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	for _, ev := range []*SlowEvaluator{
+		NewSlowEvaluatorWithStrategy(f, GeneratorPowerPoints),
+		NewSeededSlowEvaluator(f, 7),
+	} {
+		prms, err := NewCodeParameters(ev, 18, 5)
+		a.NoError(err)
+
+		gao := NewCodeGao(prms)
+
+		codeword, err := gao.EncodeOrdered(makeTestSlice(5))
+		a.NoError(err)
+
+		codeword[0] = f.Add(codeword[0], 1)
+		codeword[5] = f.Add(codeword[5], 1)
+
+		decoded, err := gao.DecodeOrdered(codeword, nil)
+		a.NoError(err)
+		a.Equal(makeTestSlice(5), decoded)
+	}
+}
+
+func TestSlowEncodeMatchesHorner(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	pr := field.NewDensePolyRing(f)
+
+	// Encode pads the message to n, thus sequential points sweep the padded polynomial by forward differences.
+	for _, ev := range []*SlowEvaluator{NewSlowEvaluator(f), NewSlowEvaluatorWithStrategy(f, GeneratorPowerPoints)} {
+		for _, nk := range [][2]int{{18, 5}, {64, 1}, {64, 2}, {200, 64}} {
+			n, k := nk[0], nk[1]
+
+			prms, err := NewCodeParameters(ev, n, k)
+			a.NoError(err)
+
+			data := make([]uint64, k)
+			for i := range data {
+				data[i] = f.Reduce(uint64(i+1) * 0x9e3779b97f4a7c15)
+			}
+
+			codeword, err := NewCodeGao(prms).EncodeOrdered(data)
+			a.NoError(err)
+
+			p := field.NewPolynomial(f, data, false)
+			for i, x := range ev.EvaluationPoints(n) {
+				a.Equal(pr.Evaluate(p, x), codeword[i], "%v n=%d k=%d i=%d", ev.Strategy(), n, k, i)
+			}
+		}
+	}
+}
+
+func TestAutoEvaluator(t *testing.T) {
+	a := assert.New(t)
+
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	// 157-1 = 4*39, no roots of unity of order 1024.
+	f157, err := field.NewPrimeField(157)
+	a.NoError(err)
+
+	a.True(NewAutoEvaluator(f, 1024).UsesNTT())
+	a.False(NewAutoEvaluator(f, 8).UsesNTT())    // too small to pay off.
+	a.False(NewAutoEvaluator(f, 1000).UsesNTT()) // not a power of two.
+	a.False(NewAutoEvaluator(f157, 128).UsesNTT())
+
+	for _, ev := range []*AutoEvaluator{NewAutoEvaluator(f, 64), NewAutoEvaluator(f, 18)} {
+		n := 64
+		if !ev.UsesNTT() {
+			n = 18
+		}
+
+		prms, err := NewCodeParameters(ev, n, 5)
+		a.NoError(err)
+
+		gao := NewCodeGao(prms)
+
+		codeword, err := gao.EncodeOrdered(makeTestSlice(5))
+		a.NoError(err)
+
+		codeword[1] = f.Add(codeword[1], 1)
+
+		decoded, err := gao.DecodeOrdered(codeword, nil)
+		a.NoError(err)
+		a.Equal(makeTestSlice(5), decoded)
+	}
+}
+
+func TestNttEvaluationPoints(t *testing.T) {
+	a := assert.New(t)
+
+	for _, prime := range []uint64{65537, 4611685318347718657} {
+		f, err := field.NewPrimeField(prime)
+		a.NoError(err)
+
+		ev := NewNttEvaluator(f)
+		pr := field.NewDensePolyRing(f)
+
+		for _, n := range []int{2, 4, 16, 256, 1 << 12} {
+			// the NTT of p(x) = x.
+			inner := make([]uint64, n)
+			inner[1] = 1
+			p := field.NewPolynomial(f, inner, false)
+			a.NoError(pr.NttForward(p))
+
+			a.Equal(p.ToSlice(), ev.EvaluationPoints(n), "prime=%d n=%d", prime, n)
+		}
+	}
+
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	a.Equal([]uint64{1}, NewNttEvaluator(f).EvaluationPoints(1))
+
+	// 7 has no roots of unity of order 8.
+	f, err = field.NewPrimeField(7)
+	a.NoError(err)
+	a.Panics(func() { NewNttEvaluator(f).EvaluationPoints(8) })
+}
+
+func TestValidateNttCode(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	for _, nk := range [][2]int{{1, 1}, {2, 1}, {16, 4}, {1 << 10, 512}, {1 << 16, 1 << 16}} {
+		n, k := nk[0], nk[1]
+		a.NoError(ValidateNttCode(f, n, k), "n=%d k=%d", n, k)
+
+		// a valid configuration encodes and decodes.
+		if n <= 1<<10 {
+			prms, err := NewCodeParameters(NewNttEvaluator(f), n, k)
+			a.NoError(err)
+
+			gao := NewCodeGao(prms)
+			encoded, err := gao.Encode(makeTestSlice(k))
+			a.NoError(err)
+
+			decoded, err := gao.Decode(encoded)
+			a.NoError(err)
+			a.Equal(makeTestSlice(k), decoded)
+		}
+	}
+
+	a.ErrorIs(ValidateNttCode(f, 16, 17), ErrNSmallerThanK)
+	a.NoError(ValidateNttCode(f, 12, 4)) // the first 12 roots of unity of order 16.
+
+	// 2^17 points don't fit in the field.
+	a.ErrorIs(ValidateNttCode(f, 1<<17, 4), ErrDomainTooSmall)
+
+	// 157-1 = 4*39 has no roots of unity of order 8, and largePrime's p-1 is 2 times an odd number.
+	for _, prime := range []uint64{157, 9191248642791733759} {
+		f, err := field.NewPrimeField(prime)
+		a.NoError(err)
+
+		for _, n := range []int{5, 8} {
+			err = ValidateNttCode(f, n, 4)
+			a.ErrorIs(err, ErrNoRootOfUnity)
+			a.NotErrorIs(err, ErrDomainTooSmall)
+			a.Contains(err.Error(), fmt.Sprintf("n=%d", n))
+		}
+	}
+
+	// 2^61-1 has plenty of points, but p-1 = 2 * (2^60 - 1) has no roots of unity of order 1024.
+	mersenne, err := field.NewMersennePrimeField(61)
+	a.NoError(err)
+
+	err = ValidateNttCode(mersenne, 1024, 512)
+	a.ErrorIs(err, ErrNoRootOfUnity)
+	a.Contains(err.Error(), "nextPow2(1024) = 1024")
+
+	// agrees with NewCodeParameters.
+	for _, nk := range [][2]int{{0, 0}, {16, 0}, {16, 16}, {4, 5}, {1 << 16, 1 << 15}} {
+		_, err := NewCodeParameters(NewNttEvaluator(f), nk[0], nk[1])
+		a.ErrorIs(ValidateNttCode(f, nk[0], nk[1]), err, "n=%d k=%d", nk[0], nk[1])
+	}
+}
+
+func TestDomain(t *testing.T) {
+	a := assert.New(t)
+
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	coset, err := NewCosetNttEvaluator(f, f.Generator())
+	a.NoError(err)
+
+	pr := field.NewDensePolyRing(f)
+
+	evaluators := []EvaluationMap{
+		NewSlowEvaluator(f),
+		NewSlowEvaluatorWithStrategy(f, GeneratorPowerPoints),
+		NewNttEvaluator(f),
+		coset,
+		NewAutoEvaluator(f, 64),
+	}
+
+	for _, ev := range evaluators {
+		for _, n := range []int{1, 16, 61, 64} {
+			points, locator, err := ev.Domain(n)
+			a.NoError(err, "%T n=%d", ev, n)
+			a.Equal(ev.EvaluationPoints(n), points)
+
+			a.Equal(n, locator.Degree())
+			a.True(locator.IsMonic())
+			a.True(field.PolyProductMonicNegRoots(f, points).Equals(locator), "%T n=%d", ev, n)
+
+			for _, x := range points {
+				a.Zero(pr.Evaluate(locator, x))
+			}
+
+			// cached.
+			_, again, err := ev.Domain(n)
+			a.NoError(err)
+			a.Same(locator, again)
+		}
+	}
+
+	// 64 points, but 3.
+	punctured := NewPuncturedEvaluator(NewNttEvaluator(f), NewNttEvaluator(f).EvaluationPoints(64)[:3])
+
+	points, locator, err := punctured.Domain(61)
+	a.NoError(err)
+	a.Equal(punctured.EvaluationPoints(61), points)
+	a.True(field.PolyProductMonicNegRoots(f, points).Equals(locator))
+
+	// the first 19 roots of unity of order 32 lack the dropped root of order 64.
+	_, _, err = punctured.Domain(16)
+	a.ErrorIs(err, ErrUnknownPuncturedPoint)
+
+	points, _, err = NewNttEvaluator(f).Domain(12)
+	a.NoError(err)
+	a.Equal(NewNttEvaluator(f).EvaluationPoints(16)[:12], points)
+
+	_, _, err = NewNttEvaluator(f).Domain(1 << 17)
+	a.Error(err)
+
+	small, err := field.NewPrimeField(157)
+	a.NoError(err)
+
+	_, _, err = NewSlowEvaluator(small).Domain(157)
+	a.ErrorIs(err, errTooManyEvaluationPoints)
+
+	_, _, err = NewPuncturedEvaluator(NewNttEvaluator(f), []uint64{0}).Domain(15)
+	a.ErrorIs(err, ErrUnknownPuncturedPoint)
+}
+
+func TestCosetNttEvaluator(t *testing.T) {
+	a := assert.New(t)
+
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	_, err = NewCosetNttEvaluator(f, 65537)
+	a.ErrorIs(err, ErrZeroCosetShift)
+
+	// a root of unity of order 16 shifts the subgroups of order 16 and above onto themselves, but not smaller ones.
+	w, err := f.GetRootOfUnity(16)
+	a.NoError(err)
+
+	for _, shift := range []uint64{1, w} {
+		inSubgroup, err := NewCosetNttEvaluator(f, shift)
+		a.NoError(err)
+
+		_, err = NewCodeParameters(inSubgroup, 16, 4)
+		a.ErrorIs(err, ErrCosetShiftInSubgroup)
+
+		_, err = NewCodeParameters(inSubgroup, 20, 4)
+		a.ErrorIs(err, ErrCosetShiftInSubgroup)
+
+		_, _, err = inSubgroup.Domain(16)
+		a.ErrorIs(err, ErrCosetShiftInSubgroup)
+	}
+
+	inSubgroup, err := NewCosetNttEvaluator(f, w)
+	a.NoError(err)
+
+	_, err = NewCodeParameters(inSubgroup, 8, 4)
+	a.NoError(err)
+
+	ev, err := NewCosetNttEvaluator(f, f.Generator())
+	a.NoError(err)
+
+	ntt := NewNttEvaluator(f)
+	slow := NewSlowEvaluator(f)
+
+	n := 16
+	xs := ev.EvaluationPoints(n)
+
+	subgroup := make(map[uint64]bool, n)
+	for _, w := range ntt.EvaluationPoints(n) {
+		subgroup[w] = true
+	}
+
+	locator := ev.GenerateLocatorPolynomial(n)
+	a.Equal(n, locator.Degree())
+	a.Equal(uint64(1), locator.LeadCoeff())
+
+	for i, x := range xs {
+		a.Equal(f.Mul(f.Generator(), ntt.EvaluationPoints(n)[i]), x)
+		a.False(subgroup[x]) // a disjoint coset.
+		a.Zero(slow.pr.Evaluate(locator, x))
+	}
+
+	// evaluation agrees with plain evaluation, and interpolation inverts it.
+	p := field.NewPolynomial(f, makeTestSlice(n), false)
+	ys, err := ev.EvaluatePolynomial(p.Copy())
+	a.NoError(err)
+
+	for i, x := range xs {
+		a.Equal(slow.pr.Evaluate(p, x), ys[i])
+	}
+
+	g1, err := ev.interpolate(ys)
+	a.NoError(err)
+	a.True(p.Equals(g1))
+
+	// length 5 is padded to 8, and truncated back to the first 5 points.
+	p = field.NewPolynomial(f, makeTestSlice(5), false)
+	ys, err = ev.EvaluatePolynomial(p)
+	a.NoError(err)
+	a.Len(ys, 5)
+
+	for i, x := range ev.EvaluationPoints(5) {
+		a.Equal(slow.pr.Evaluate(p, x), ys[i])
+	}
+
+	_, err = ev.EvaluatePolynomial(field.NewPolynomial(f, make([]uint64, n), true))
+	a.Error(err)
+}
+
+func TestRandomEvaluator(t *testing.T) {
+	a := assert.New(t)
+
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	for _, n := range []int{16, 18} { // coset NTT and random points, respectively.
+		ev1 := NewRandomEvaluator(f, n, 42)
+		ev2 := NewRandomEvaluator(f, n, 42)
+
+		a.Equal(n == 16, ev1.isNTT())
+		a.Equal(ev1.EvaluationPoints(n), ev2.EvaluationPoints(n))
+		a.True(ev1.GenerateLocatorPolynomial(n).Equals(ev2.GenerateLocatorPolynomial(n)))
+		a.NotEqual(ev1.EvaluationPoints(n), NewRandomEvaluator(f, n, 43).EvaluationPoints(n))
+
+		// an encoder and a decoder sharing the seed.
+		encoderPrms, err := NewCodeParameters(ev1, n, 4)
+		a.NoError(err)
+
+		decoderPrms, err := NewCodeParameters(ev2, n, 4)
+		a.NoError(err)
+
+		encoded, err := NewCodeGao(encoderPrms).Encode(makeTestSlice(4))
+		a.NoError(err)
+
+		x := ev1.EvaluationPoints(n)[2]
+		encoded[x] = f.Add(encoded[x], 1)
+
+		decoded, err := NewCodeGao(decoderPrms).Decode(encoded)
+		a.NoError(err)
+		a.Equal(makeTestSlice(4), decoded)
+	}
+
+	// half of the shifts over 17 are in the subgroup of order 8, and all of them are in the one of order 16.
+	small, err := field.NewPrimeField(17)
+	a.NoError(err)
+
+	for _, n := range []int{8, 16} {
+		for seed := int64(0); seed < 200; seed++ {
+			ev := NewRandomEvaluator(small, n, seed)
+			a.Equal(n == 8, ev.isNTT())
+
+			_, err := NewCodeParameters(ev, n, n/2)
+			a.NoError(err, "n=%d seed=%d", n, seed)
+			a.Equal(ev.EvaluationPoints(n), NewRandomEvaluator(small, n, seed).EvaluationPoints(n))
+		}
+	}
+}
+
+func TestPuncturedEvaluator(t *testing.T) {
+	a := assert.New(t)
+
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	pr := field.NewDensePolyRing(f)
+
+	for _, base := range []testCase{
+		{NewSlowEvaluator(f), 18, 5},
+		{NewNttEvaluator(f), 16, 4},
+	} {
+		baseXs := base.EvaluationPoints(base.n)
+		drop := []uint64{baseXs[3], baseXs[10], baseXs[3]}
+
+		ev := NewPuncturedEvaluator(base.EvaluationMap, drop)
+		n := base.n - 2
+
+		xs := ev.EvaluationPoints(n)
+		a.Len(xs, n)
+		a.NotContains(xs, baseXs[3])
+		a.NotContains(xs, baseXs[10])
+
+		locator := ev.GenerateLocatorPolynomial(n)
+		a.Equal(n, locator.Degree())
+
+		for _, x := range xs {
+			a.Zero(pr.Evaluate(locator, x))
+		}
+
+		prms, err := NewCodeParameters(ev, n, base.k)
+		a.NoError(err)
+
+		gao := NewCodeGao(prms)
+
+		encoded, err := gao.Encode(makeTestSlice(base.k))
+		a.NoError(err)
+		a.Len(encoded, n)
+
+		// agrees with the base code on the remaining points.
+		basePrms, err := NewCodeParameters(base.EvaluationMap, base.n, base.k)
+		a.NoError(err)
+
+		baseEncoded, err := NewCodeGao(basePrms).Encode(makeTestSlice(base.k))
+		a.NoError(err)
+
+		for x, y := range encoded {
+			a.Equal(baseEncoded[x], y)
+		}
+
+		for i, x := range xs[:prms.MaxErrors()] {
+			encoded[x] = f.Add(encoded[x], uint64(i+1))
+		}
+
+		decoded, err := gao.Decode(encoded)
+		a.NoError(err)
+		a.Equal(makeTestSlice(base.k), decoded)
+	}
+
+	ev := NewPuncturedEvaluator(NewSlowEvaluator(f), []uint64{1000})
+	a.Nil(ev.EvaluationPoints(10))
+
+	_, err = NewCodeParameters(ev, 10, 4)
+	a.ErrorIs(err, ErrUnknownPuncturedPoint)
+
+	// 156 = 4 * 39 has no roots of unity of order 8, thus no base domain of 4 + 1 points.
+	small, err := field.NewPrimeField(157)
+	a.NoError(err)
+
+	ntt := NewNttEvaluator(small)
+	_, err = NewCodeParameters(NewPuncturedEvaluator(ntt, ntt.EvaluationPoints(4)[:1]), 4, 2)
+	a.ErrorIs(err, ErrNoRootOfUnity)
+
+	_, err = NewCodeParameters(NewPuncturedEvaluator(ntt, ntt.EvaluationPoints(4)[:1]), 3, 2)
+	a.NoError(err)
+}
@@ -0,0 +1,297 @@
+package field
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/bits"
+)
+
+/*
+BinaryField is GF(2^m), for 2 <= m <= 16. Elements are the polynomials over GF(2) of degree < m,
+encoded as bit vectors (bit i is the coefficient of x^i), and arithmetic is modulo a fixed irreducible polynomial.
+Add and Sub are XOR; Mul and Inverse use log/antilog tables of a generator of the multiplicative group.
+
+Modulus returns the field order 2^m (it bounds the elements, like a prime bounds a PrimeField's elements).
+The multiplicative group has odd order 2^m - 1, thus there are no power-of-two roots of unity, and NTT is not
+supported. DensePolyRing.Roots assumes an odd characteristic and doesn't support binary fields.
+*/
+type BinaryField struct {
+	m         uint
+	order     uint64 // 2^m
+	poly      uint64 // the irreducible polynomial, including the x^m term.
+	generator uint64
+	factors   []uint64
+
+	exp []uint64 // exp[i] = g^i, doubled to skip reducing log sums.
+	log []int    // log[g^i] = i, log[0] is unused.
+}
+
+var (
+	errBinaryFieldDegree   = errors.New("binary field degree m must be between 2 and 16")
+	errReduciblePolynomial = errors.New("polynomial is not irreducible of degree m")
+)
+
+// primitivePolynomials[m] is a primitive polynomial of degree m, for which x (i.e., 2) is a generator.
+var primitivePolynomials = map[uint]uint64{
+	2: 0x7, 3: 0xb, 4: 0x13, 5: 0x25, 6: 0x43, 7: 0x89, 8: 0x11d, 9: 0x211,
+	10: 0x409, 11: 0x805, 12: 0x1053, 13: 0x201b, 14: 0x4443, 15: 0x8003, 16: 0x1100b,
+}
+
+// NewBinaryField returns GF(2^m) with a standard primitive polynomial, e.g., x^8+x^4+x^3+x^2+1 (0x11d) for m = 8,
+// as common in Reed-Solomon implementations.
+func NewBinaryField(m uint) (Field, error) {
+	poly, ok := primitivePolynomials[m]
+	if !ok {
+		return nil, errBinaryFieldDegree
+	}
+
+	return NewBinaryFieldWithPolynomial(m, poly)
+}
+
+// NewBinaryFieldWithPolynomial returns GF(2^m) modulo poly, which must be irreducible of degree m,
+// e.g., the AES polynomial x^8+x^4+x^3+x+1 (0x11b).
+func NewBinaryFieldWithPolynomial(m uint, poly uint64) (Field, error) {
+	if m < 2 || m > 16 {
+		return nil, errBinaryFieldDegree
+	}
+
+	if bits.Len64(poly) != int(m)+1 {
+		return nil, errReduciblePolynomial
+	}
+
+	f := &BinaryField{
+		m:       m,
+		order:   1 << m,
+		poly:    poly,
+		factors: primeFactors(1<<m - 1),
+	}
+
+	if !f.isIrreducible() {
+		return nil, errReduciblePolynomial
+	}
+
+	for g := uint64(2); g < f.order; g++ {
+		if f.hasFullOrder(g) {
+			f.generator = g
+			break
+		}
+	}
+
+	groupOrder := int(f.order - 1)
+	f.exp = make([]uint64, 2*groupOrder)
+	f.log = make([]int, f.order)
+
+	x := uint64(1)
+	for i := 0; i < groupOrder; i++ {
+		f.exp[i], f.exp[i+groupOrder] = x, x
+		f.log[x] = i
+		x = f.slowMul(x, f.generator)
+	}
+
+	return f, nil
+}
+
+// primeFactors returns the distinct prime factors of n.
+func primeFactors(n uint64) []uint64 {
+	var factors []uint64
+	for d := uint64(2); d*d <= n; d++ {
+		if n%d == 0 {
+			factors = append(factors, d)
+			for n%d == 0 {
+				n /= d
+			}
+		}
+	}
+
+	if n > 1 {
+		factors = append(factors, n)
+	}
+
+	return factors
+}
+
+// slowMul is the carry-less product of a and b modulo poly.
+func (f *BinaryField) slowMul(a, b uint64) uint64 {
+	res := uint64(0)
+	for ; b > 0; b >>= 1 {
+		if b&1 == 1 {
+			res ^= a
+		}
+
+		a <<= 1
+		if a&f.order != 0 {
+			a ^= f.poly
+		}
+	}
+
+	return res
+}
+
+func (f *BinaryField) slowPow(base, exp uint64) uint64 {
+	x := uint64(1)
+	for ; exp > 0; exp >>= 1 {
+		if exp&1 == 1 {
+			x = f.slowMul(x, base)
+		}
+
+		base = f.slowMul(base, base)
+	}
+
+	return x
+}
+
+// hasFullOrder reports whether g generates the multiplicative group.
+func (f *BinaryField) hasFullOrder(g uint64) bool {
+	groupOrder := f.order - 1
+	for _, q := range f.factors {
+		if f.slowPow(g, groupOrder/q) == 1 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isIrreducible reports whether poly is irreducible, using Rabin's test:
+// x^(2^m) = x (mod poly), and gcd(x^(2^(m/q)) - x, poly) = 1 for every prime q dividing m.
+func (f *BinaryField) isIrreducible() bool {
+	xPow := func(k uint) uint64 { // x^(2^k) mod poly.
+		x := uint64(2)
+		for i := uint(0); i < k; i++ {
+			x = f.slowMul(x, x)
+		}
+
+		return x
+	}
+
+	if xPow(f.m) != 2 {
+		return false
+	}
+
+	for _, q := range primeFactors(uint64(f.m)) {
+		if gf2PolyGcd(xPow(f.m/uint(q))^2, f.poly) != 1 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// gf2PolyGcd returns the gcd of two polynomials over GF(2), encoded as bit vectors.
+func gf2PolyGcd(a, b uint64) uint64 {
+	for b != 0 {
+		// a mod b.
+		for db := bits.Len64(b); bits.Len64(a) >= db; {
+			a ^= b << (bits.Len64(a) - db)
+		}
+
+		a, b = b, a
+	}
+
+	return a
+}
+
+func (f *BinaryField) Equals(a, b uint64) bool {
+	return f.Reduce(a) == f.Reduce(b)
+}
+
+func (f *BinaryField) Add(a, b uint64) uint64 {
+	return a ^ b
+}
+
+func (f *BinaryField) Sub(a, b uint64) uint64 {
+	return a ^ b
+}
+
+func (f *BinaryField) Mul(a, b uint64) uint64 {
+	if a == 0 || b == 0 {
+		return 0
+	}
+
+	return f.exp[f.log[a]+f.log[b]]
+}
+
+func (f *BinaryField) Pow(base, exp uint64) uint64 {
+	base = f.Reduce(base)
+	if base == 0 {
+		if exp == 0 {
+			return 1
+		}
+
+		return 0
+	}
+
+	return f.exp[(uint64(f.log[base])*(exp%(f.order-1)))%(f.order-1)]
+}
+
+// Neg is the identity, since the field has characteristic 2.
+func (f *BinaryField) Neg(a uint64) uint64 {
+	return a
+}
+
+func (f *BinaryField) Inverse(a uint64) uint64 {
+	if a == 0 {
+		panic("zero has no inverse")
+	}
+
+	groupOrder := int(f.order - 1)
+
+	return f.exp[(groupOrder-f.log[a])%groupOrder]
+}
+
+// Reduce returns val modulo the irreducible polynomial, treating val as a polynomial over GF(2).
+func (f *BinaryField) Reduce(val uint64) uint64 {
+	if val < f.order {
+		return val
+	}
+
+	deg := int(f.m)
+	for l := bits.Len64(val); l > deg; l = bits.Len64(val) {
+		val ^= f.poly << (l - deg - 1)
+	}
+
+	return val
+}
+
+// Modulus returns the field order 2^m, see BinaryField.
+func (f *BinaryField) Modulus() uint64 {
+	return f.order
+}
+
+// RandomElement returns a uniformly random element, i.e., m random bits, drawing from src, see PrimeField.RandomElement.
+func (f *BinaryField) RandomElement(src io.Reader) (uint64, error) {
+	var buf [8]byte
+	if _, err := io.ReadFull(src, buf[:]); err != nil {
+		return 0, err
+	}
+
+	return binary.LittleEndian.Uint64(buf[:]) & (f.order - 1), nil
+}
+
+// Polynomial returns the irreducible polynomial defining the field.
+func (f *BinaryField) Polynomial() uint64 {
+	return f.poly
+}
+
+// GetRootOfUnity returns a primitive n'th root of unity, which exists when n divides 2^m - 1.
+func (f *BinaryField) GetRootOfUnity(n uint64) (uint64, error) {
+	if n == 0 || n == 1 {
+		return 0, errNSTooSmall
+	}
+
+	if (f.order-1)%n != 0 {
+		return 0, errNotDivisible
+	}
+
+	return f.Pow(f.generator, (f.order-1)/n), nil
+}
+
+func (f *BinaryField) Generator() uint64 {
+	return f.generator
+}
+
+// Factors returns the prime factors of the multiplicative group order 2^m - 1.
+func (f *BinaryField) Factors() []uint64 {
+	return f.factors
+}
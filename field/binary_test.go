@@ -0,0 +1,126 @@
+package field
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBinaryField(t *testing.T) {
+	a := assert.New(t)
+
+	for m := uint(2); m <= 16; m++ {
+		fld, err := NewBinaryField(m)
+		a.NoError(err, "m=%d", m)
+
+		f := fld.(*BinaryField)
+		a.Equal(uint64(1)<<m, f.Modulus())
+		a.Equal(uint64(2), f.Generator(), "m=%d: the standard polynomial should be primitive", m)
+
+		for _, x := range []uint64{1, 2, 3, f.Modulus() - 1} {
+			a.Equal(uint64(1), f.Mul(x, f.Inverse(x)))
+			a.Equal(uint64(0), f.Add(x, f.Neg(x)))
+			a.Equal(uint64(1), f.Pow(x, f.Modulus()-1))
+		}
+
+		v, err := f.RandomElement(bytes.NewReader([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}))
+		a.NoError(err)
+		a.Equal(f.Modulus()-1, v)
+	}
+
+	_, err := NewBinaryField(17)
+	a.ErrorIs(err, errBinaryFieldDegree)
+
+	_, err = NewBinaryFieldWithPolynomial(8, 0x101) // x^8 + 1 = (x+1)^8.
+	a.ErrorIs(err, errReduciblePolynomial)
+
+	_, err = NewBinaryFieldWithPolynomial(8, 0x1d) // degree 4.
+	a.ErrorIs(err, errReduciblePolynomial)
+}
+
+func TestBinaryFieldGF256Vectors(t *testing.T) {
+	a := assert.New(t)
+
+	// FIPS-197 (AES), section 4.2: {57} * {83} = {c1}, {57} * {13} = {fe}.
+	aes, err := NewBinaryFieldWithPolynomial(8, 0x11b)
+	a.NoError(err)
+	a.Equal(uint64(0xc1), aes.Mul(0x57, 0x83))
+	a.Equal(uint64(0xfe), aes.Mul(0x57, 0x13))
+	a.Equal(uint64(0xca), aes.Inverse(0x53)) // FIPS-197, section 5.1.1.
+	a.Equal(uint64(3), aes.Generator())
+
+	// 0x11d, used by Reed-Solomon implementations: the powers of 2.
+	rs, err := NewBinaryField(8)
+	a.NoError(err)
+
+	expected := []uint64{1, 2, 4, 8, 16, 32, 64, 128, 29, 58, 116, 232, 205, 135, 19, 38, 76, 152, 45, 90, 180, 117}
+	for i, e := range expected {
+		a.Equal(e, rs.Pow(2, uint64(i)))
+	}
+
+	// tables agree with carry-less multiplication, for all pairs.
+	for _, fld := range []Field{aes, rs} {
+		f := fld.(*BinaryField)
+		for x := uint64(0); x < 256; x++ {
+			for y := uint64(0); y < 256; y++ {
+				if f.Mul(x, y) != f.slowMul(x, y) {
+					t.Fatalf("poly=%#x: Mul(%d, %d) = %d, want %d", f.Polynomial(), x, y, f.Mul(x, y), f.slowMul(x, y))
+				}
+			}
+		}
+	}
+
+	// Reduce treats values as polynomials over GF(2).
+	a.Equal(uint64(0x1d), rs.Reduce(0x100))
+	a.Equal(rs.Mul(0x80, 0x80), rs.Reduce(0x4000))
+	a.True(rs.Equals(0x11d, 0))
+}
+
+func TestBinaryFieldRootsOfUnity(t *testing.T) {
+	a := assert.New(t)
+
+	f, err := NewBinaryField(8)
+	a.NoError(err)
+
+	// 255 = 3 * 5 * 17.
+	a.Equal([]uint64{3, 5, 17}, f.Factors())
+
+	for _, n := range []uint64{3, 5, 15, 17, 51, 85, 255} {
+		root, err := f.GetRootOfUnity(n)
+		a.NoError(err)
+		a.True(isRootOfUnityOfOrderN(f, root, n))
+	}
+
+	_, err = f.GetRootOfUnity(4)
+	a.ErrorIs(err, errNotDivisible)
+}
+
+func TestBinaryFieldPolyRing(t *testing.T) {
+	a := assert.New(t)
+
+	f, err := NewBinaryField(16)
+	a.NoError(err)
+
+	pr := NewDensePolyRing(f, WithMulThreshold(4)) // no NTT: falls back to schoolbook.
+
+	p := NewPolynomial(f, []uint64{1, 2, 3, 4, 5, 6, 7, 8}, false)
+	q := NewPolynomial(f, []uint64{9, 10, 11}, false)
+
+	prod := &Polynomial{}
+	pr.MulPoly(p, q, prod)
+
+	quo, rem := pr.LongDiv(prod, q)
+	a.True(quo.Equals(p))
+	a.True(rem.IsZero())
+
+	xs := []uint64{1, 2, 3, 4, 5, 6, 7, 8}
+	ys := make([]uint64, len(xs))
+	for i, x := range xs {
+		ys[i] = pr.Evaluate(p, x)
+	}
+
+	interpolated, err := NewInterpolator(pr).Interpolate(xs, ys)
+	a.NoError(err)
+	a.True(interpolated.Equals(p))
+}
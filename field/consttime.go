@@ -0,0 +1,68 @@
+package field
+
+import (
+	"math/bits"
+)
+
+/*
+ConstantTimeField is a PrimeField whose Add, Sub, Neg and Mul don't branch on their inputs:
+the zero short-circuits and conditional reductions of PrimeField are replaced with masks.
+
+Inputs must be reduced. Mul still relies on bits.Div64, whose latency depends on the hardware
+(on some CPUs the DIV instruction is not constant-time). Pow and Inverse branch only on the exponent,
+which for Inverse is the public p-2; Inverse still panics on zero.
+*/
+type ConstantTimeField struct {
+	*PrimeField
+}
+
+// NewConstantTimeField returns the prime field of the given order, with branch-free arithmetic.
+func NewConstantTimeField(prime uint64) (Field, error) {
+	f, err := NewPrimeField(prime)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ConstantTimeField{PrimeField: f.(*PrimeField)}, nil
+}
+
+// Clone returns a copy of f, see PrimeField.Clone.
+func (f *ConstantTimeField) Clone() Field {
+	return &ConstantTimeField{PrimeField: f.PrimeField.Clone().(*PrimeField)}
+}
+
+// ctSelect returns a if mask is all ones, and b if mask is zero.
+func ctSelect(mask, a, b uint64) uint64 {
+	return (a & mask) | (b &^ mask)
+}
+
+func (f *ConstantTimeField) Add(a, b uint64) uint64 {
+	return f.addBranchless(a, b)
+}
+
+func (f *ConstantTimeField) Sub(a, b uint64) uint64 {
+	diff, borrow := bits.Sub64(a, b, 0)
+
+	return diff + (f.prime & -borrow)
+}
+
+func (f *ConstantTimeField) Neg(e uint64) uint64 {
+	return f.Sub(0, e)
+}
+
+func (f *ConstantTimeField) Mul(a, b uint64) uint64 {
+	return fieldMul(a, b, f.prime)
+}
+
+// AddReduced, SubReduced and MulReduced shadow PrimeField's, which would call its branching ops.
+func (f *ConstantTimeField) AddReduced(a, b uint64) uint64 {
+	return f.Add(f.Reduce(a), f.Reduce(b))
+}
+
+func (f *ConstantTimeField) SubReduced(a, b uint64) uint64 {
+	return f.Sub(f.Reduce(a), f.Reduce(b))
+}
+
+func (f *ConstantTimeField) MulReduced(a, b uint64) uint64 {
+	return f.Mul(f.Reduce(a), f.Reduce(b))
+}
@@ -0,0 +1,67 @@
+package field
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConstantTimeField(t *testing.T) {
+	a := assert.New(t)
+
+	check := func(prime uint64, elems []uint64) {
+		ref, err := NewPrimeField(prime)
+		a.NoError(err)
+
+		ct, err := NewConstantTimeField(prime)
+		a.NoError(err)
+
+		for _, x := range elems {
+			if ct.Neg(x) != ref.Neg(x) {
+				t.Fatalf("p=%d: Neg(%d) = %d, want %d", prime, x, ct.Neg(x), ref.Neg(x))
+			}
+
+			for _, y := range elems {
+				if got, want := ct.Add(x, y), ref.Add(x, y); got != want {
+					t.Fatalf("p=%d: Add(%d, %d) = %d, want %d", prime, x, y, got, want)
+				}
+
+				if got, want := ct.Sub(x, y), ref.Sub(x, y); got != want {
+					t.Fatalf("p=%d: Sub(%d, %d) = %d, want %d", prime, x, y, got, want)
+				}
+
+				if got, want := ct.Mul(x, y), ref.Mul(x, y); got != want {
+					t.Fatalf("p=%d: Mul(%d, %d) = %d, want %d", prime, x, y, got, want)
+				}
+			}
+		}
+	}
+
+	// all pairs of a small field.
+	all := make([]uint64, 157)
+	for i := range all {
+		all[i] = uint64(i)
+	}
+
+	check(157, all)
+
+	// zeros, edges and random elements of larger fields.
+	rnd := rand.New(rand.NewSource(1))
+	for _, prime := range []uint64{65537, 9191248642791733759} {
+		elems := []uint64{0, 1, 2, prime / 2, prime/2 + 1, prime - 2, prime - 1}
+		for i := 0; i < 50; i++ {
+			elems = append(elems, rnd.Uint64()%prime)
+		}
+
+		check(prime, elems)
+	}
+
+	ct, err := NewConstantTimeField(65537)
+	a.NoError(err)
+	a.Equal(uint64(1), ct.Mul(12345, ct.Inverse(12345)))
+	a.Equal(uint64(3), ct.(*ConstantTimeField).AddReduced(65537+1, 2))
+
+	_, err = NewConstantTimeField(65536)
+	a.ErrorIs(err, errNotPrime)
+}
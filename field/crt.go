@@ -0,0 +1,63 @@
+package field
+
+import (
+	"errors"
+	"math/big"
+)
+
+var (
+	errCRTSizeMismatch  = errors.New("residues and fields must be of the same length")
+	errCRTNoModuli      = errors.New("at least one field is required")
+	errModuliNotCoprime = errors.New("moduli must be pairwise coprime")
+)
+
+// CRTReconstruct returns the unique integer x in [0, prod(moduli)) such that
+// x = residues[i] (mod fields[i].Modulus()) for every i.
+// Residues are reduced by their field before reconstruction.
+func CRTReconstruct(residues []uint64, fields []Field) (*big.Int, error) {
+	if len(residues) != len(fields) {
+		return nil, errCRTSizeMismatch
+	}
+
+	if len(fields) == 0 {
+		return nil, errCRTNoModuli
+	}
+
+	moduli := make([]*big.Int, len(fields))
+	for i, f := range fields {
+		moduli[i] = new(big.Int).SetUint64(f.Modulus())
+	}
+
+	gcd := &big.Int{}
+	for i := range moduli {
+		for j := i + 1; j < len(moduli); j++ {
+			if gcd.GCD(nil, nil, moduli[i], moduli[j]).Cmp(big.NewInt(1)) != 0 {
+				return nil, errModuliNotCoprime
+			}
+		}
+	}
+
+	// x = \sum r_i * M_i * (M_i^{-1} mod m_i)  (mod M), where M_i = M / m_i.
+	M := big.NewInt(1)
+	for _, m := range moduli {
+		M.Mul(M, m)
+	}
+
+	x := &big.Int{}
+	Mi := &big.Int{}
+	inv := &big.Int{}
+	term := &big.Int{}
+
+	for i, m := range moduli {
+		Mi.Div(M, m)
+		inv.ModInverse(Mi, m)
+
+		term.SetUint64(fields[i].Reduce(residues[i]))
+		term.Mul(term, Mi)
+		term.Mul(term, inv)
+
+		x.Add(x, term)
+	}
+
+	return x.Mod(x, M), nil
+}
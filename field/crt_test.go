@@ -0,0 +1,53 @@
+package field
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCRTReconstruct(t *testing.T) {
+	a := assert.New(t)
+
+	f1, err := NewPrimeField(65537)
+	a.NoError(err)
+
+	f2, err := NewPrimeField(157)
+	a.NoError(err)
+
+	fields := []Field{f1, f2}
+
+	for _, v := range []uint64{0, 1, 156, 65536, 1234567, 65537*157 - 1} {
+		residues := []uint64{v % 65537, v % 157}
+
+		x, err := CRTReconstruct(residues, fields)
+		a.NoError(err)
+		a.Equal(new(big.Int).SetUint64(v), x)
+	}
+
+	f3, err := NewPrimeField(largePrime)
+	a.NoError(err)
+
+	// product exceeds 64 bits.
+	expected, ok := new(big.Int).SetString("123456789012345678901234", 10)
+	a.True(ok)
+
+	residues := make([]uint64, 3)
+	for i, f := range []Field{f1, f2, f3} {
+		residues[i] = new(big.Int).Mod(expected, new(big.Int).SetUint64(f.Modulus())).Uint64()
+	}
+
+	x, err := CRTReconstruct(residues, []Field{f1, f2, f3})
+	a.NoError(err)
+	a.Equal(expected, x)
+
+	_, err = CRTReconstruct([]uint64{1, 2}, []Field{f1, f1})
+	a.ErrorIs(err, errModuliNotCoprime)
+
+	_, err = CRTReconstruct([]uint64{1}, fields)
+	a.ErrorIs(err, errCRTSizeMismatch)
+
+	_, err = CRTReconstruct(nil, nil)
+	a.ErrorIs(err, errCRTNoModuli)
+}
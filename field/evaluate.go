@@ -0,0 +1,149 @@
+package field
+
+/*
+EvaluateDomain returns p(x) for every x in xs, in the order of xs, choosing the cheapest strategy by the
+structure of xs:
+  - a (shifted) run of roots of unity g*w^i, where w is the root of unity of order m = nextPow2(len(xs)):
+    one forward NTT of size m over p(g*x) mod x^m - 1. The NTT evaluators use it for lengths that aren't a
+    power of two, and transform full subgroups in place instead.
+  - an arithmetic progression x_0 + i*h (e.g., SequentialPoints) of more than deg(p)+1 points: Horner on the
+    first deg(p)+1 points, then a forward-difference sweep costing deg(p) additions, and no multiplications,
+    per remaining point. Trailing zero coefficients, e.g., of a message padded to the code length, don't count.
+  - anything else: Horner per point.
+
+p isn't mutated. It returns ErrDomainMismatch if p is in NTT form, and ErrModulusMismatch if p is over another field.
+*/
+func (r *DensePolyRing) EvaluateDomain(p *Polynomial, xs []uint64) ([]uint64, error) {
+	if p.isNTT {
+		return nil, ErrDomainMismatch
+	}
+
+	if !SameField(p.f, r.Field) {
+		return nil, ErrModulusMismatch
+	}
+
+	if len(xs) == 0 {
+		return []uint64{}, nil
+	}
+
+	if shift, w, ok := r.rootOfUnityRun(xs); ok {
+		return r.evaluateRootsOfUnity(p, shift, w, len(xs))
+	}
+
+	if r.sweepsForwardDifferences(p, xs) {
+		return r.evaluateForwardDifferences(p, xs), nil
+	}
+
+	return r.evaluateHorner(p, xs), nil
+}
+
+// sweepsForwardDifferences reports whether EvaluateDomain sweeps p over xs by forward differences, which pays off
+// once xs has points beyond the first deg(p)+1.
+func (r *DensePolyRing) sweepsForwardDifferences(p *Polynomial, xs []uint64) bool {
+	d := p.Degree()
+
+	return d >= 1 && d+1 < len(xs) && r.isArithmeticProgression(xs)
+}
+
+// rootOfUnityRun reports whether xs[i] = shift*w^i, for the root of unity w of order nextPow2(len(xs)).
+func (r *DensePolyRing) rootOfUnityRun(xs []uint64) (shift, w uint64, ok bool) {
+	if len(xs) < 2 || xs[0] == 0 {
+		return 0, 0, false
+	}
+
+	w, err := r.GetRootOfUnity(uint64(nextPow2(len(xs))))
+	if err != nil {
+		return 0, 0, false
+	}
+
+	for i := 1; i < len(xs); i++ {
+		if xs[i] != r.Mul(xs[i-1], w) {
+			return 0, 0, false
+		}
+	}
+
+	return xs[0], w, true
+}
+
+// evaluateRootsOfUnity evaluates p over shift*w^i for i < n with a single forward NTT of size nextPow2(n).
+// p(shift*x) is folded modulo x^m - 1 first, as w^m = 1, thus p can be of any degree.
+func (r *DensePolyRing) evaluateRootsOfUnity(p *Polynomial, shift, w uint64, n int) ([]uint64, error) {
+	m := nextPow2(n)
+	values := r.makeCoeffs(m)
+
+	pow := uint64(1)
+	for i, c := range p.inner {
+		values[i&(m-1)] = r.Add(values[i&(m-1)], r.Mul(c, pow))
+		pow = r.Mul(pow, shift)
+	}
+
+	if err := r.NttForwardSlice(values); err != nil {
+		return nil, err
+	}
+
+	return values[:n], nil
+}
+
+// isArithmeticProgression reports whether xs[i] = xs[0] + i*step for a fixed step.
+func (r *DensePolyRing) isArithmeticProgression(xs []uint64) bool {
+	step := r.Sub(xs[1], xs[0])
+	for i := 2; i < len(xs); i++ {
+		if xs[i] != r.Add(xs[i-1], step) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// evaluateForwardDifferences evaluates p, of degree d < len(xs)-1, over the arithmetic progression xs.
+// The d-th forward difference of p with a fixed step is constant, thus from the differences at xs[0]
+// each next value costs d additions: delta_j(x + step) = delta_j(x) + delta_{j+1}(x).
+func (r *DensePolyRing) evaluateForwardDifferences(p *Polynomial, xs []uint64) []uint64 {
+	d := p.Degree()
+
+	deltas := make([]uint64, d+1)
+	for i := range deltas {
+		deltas[i] = r.Evaluate(p, xs[i])
+	}
+
+	for j := 1; j <= d; j++ {
+		for i := d; i >= j; i-- {
+			deltas[i] = r.Sub(deltas[i], deltas[i-1])
+		}
+	}
+
+	values := make([]uint64, len(xs))
+	values[0] = deltas[0]
+
+	// a devirtualized loop over prime fields, as in addPoly. The sweep is additions only, of reduced values
+	// with unpredictable carries, thus it adds without branching.
+	if pf, ok := r.Field.(*PrimeField); ok {
+		for i := 1; i < len(xs); i++ {
+			for j := 0; j < d; j++ {
+				deltas[j] = pf.addBranchless(deltas[j], deltas[j+1])
+			}
+
+			values[i] = deltas[0]
+		}
+	} else {
+		for i := 1; i < len(xs); i++ {
+			for j := 0; j < d; j++ {
+				deltas[j] = r.Add(deltas[j], deltas[j+1])
+			}
+
+			values[i] = deltas[0]
+		}
+	}
+
+	return values
+}
+
+func (r *DensePolyRing) evaluateHorner(p *Polynomial, xs []uint64) []uint64 {
+	values := make([]uint64, len(xs))
+	for i, x := range xs {
+		values[i] = r.Evaluate(p, x)
+	}
+
+	return values
+}
@@ -0,0 +1,148 @@
+package field
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateDomain(t *testing.T) {
+	a := assert.New(t)
+	f, err := NewPrimeField(nttFriendlyLargePrime)
+	a.NoError(err)
+
+	pr := NewDensePolyRing(f).(*DensePolyRing)
+
+	rootsOfUnity := func(n int, shift uint64) []uint64 {
+		w, err := f.GetRootOfUnity(uint64(nextPow2(n)))
+		a.NoError(err)
+
+		xs := make([]uint64, n)
+		for i, x := 0, shift; i < n; i, x = i+1, f.Mul(x, w) {
+			xs[i] = x
+		}
+
+		return xs
+	}
+
+	progression := func(n int, start, step uint64) []uint64 {
+		xs := make([]uint64, n)
+		for i, x := 0, start; i < n; i, x = i+1, f.Add(x, step) {
+			xs[i] = x
+		}
+
+		return xs
+	}
+
+	domains := map[string][]uint64{
+		"roots of unity":         rootsOfUnity(16, 1),
+		"partial roots of unity": rootsOfUnity(13, 1),
+		"coset":                  rootsOfUnity(32, 7),
+		"sequential":             progression(40, 1, 1),
+		"progression":            progression(40, f.Neg(5), 12345),
+		"arbitrary":              {3, 1, 4, 1, 5, 9, 2, 6},
+	}
+
+	for name, xs := range domains {
+		for _, deg := range []int{0, 1, 5, 15, 50} {
+			t.Run(fmt.Sprintf("%s/deg=%d", name, deg), func(t *testing.T) {
+				a := assert.New(t)
+
+				p := randomPolynomial(f, uint64(deg+len(xs)), deg+1)
+				cpy := p.Copy()
+
+				values, err := pr.EvaluateDomain(p, xs)
+				a.NoError(err)
+				a.Equal(pr.evaluateHorner(cpy, xs), values)
+				a.True(cpy.Equals(p))
+			})
+		}
+	}
+
+	p := randomPolynomial(f, 1, 8)
+	a.NoError(pr.NttForward(p))
+
+	_, err = pr.EvaluateDomain(p, []uint64{1, 2})
+	a.ErrorIs(err, ErrDomainMismatch)
+
+	other, err := NewPrimeField(157)
+	a.NoError(err)
+
+	_, err = pr.EvaluateDomain(NewPolynomial(other, []uint64{1, 2}, false), []uint64{1, 2})
+	a.ErrorIs(err, ErrModulusMismatch)
+}
+
+func TestEvaluateDomainStrategies(t *testing.T) {
+	a := assert.New(t)
+	f, err := NewPrimeField(nttFriendlyLargePrime)
+	a.NoError(err)
+
+	pr := NewDensePolyRing(f).(*DensePolyRing)
+	p := randomPolynomial(f, 42, 64)
+
+	n := 256
+	w, err := f.GetRootOfUnity(uint64(n))
+	a.NoError(err)
+
+	xs := make([]uint64, n)
+	for i, x := 0, uint64(3); i < n; i, x = i+1, f.Mul(x, w) {
+		xs[i] = x
+	}
+
+	shift, root, ok := pr.rootOfUnityRun(xs)
+	a.True(ok)
+	a.Equal(uint64(3), shift)
+	a.Equal(w, root)
+
+	viaNtt, err := pr.evaluateRootsOfUnity(p, shift, root, n)
+	a.NoError(err)
+	a.Equal(pr.evaluateHorner(p, xs), viaNtt)
+
+	for i := range xs {
+		xs[i] = uint64(i + 1)
+	}
+
+	a.True(pr.isArithmeticProgression(xs))
+	a.Equal(pr.evaluateHorner(p, xs), pr.evaluateForwardDifferences(p, xs))
+
+	// trailing zeros, e.g., of a message padded to the code length, don't count towards the degree.
+	padded := make([]uint64, n)
+	copy(padded, p.inner)
+	a.True(pr.sweepsForwardDifferences(NewPolynomial(f, padded, false), xs))
+	a.Equal(pr.evaluateHorner(p, xs), pr.evaluateForwardDifferences(NewPolynomial(f, padded, false), xs))
+
+	a.False(pr.sweepsForwardDifferences(randomPolynomial(f, 42, n), xs)) // deg(p)+1 = len(xs).
+	a.False(pr.sweepsForwardDifferences(NewPolynomial(f, []uint64{7, 0, 0}, false), xs))
+}
+
+/*
+BenchmarkEvaluateDomain/horner                	     408	   2910456 ns/op
+BenchmarkEvaluateDomain/forward-differences   	     810	   1715799 ns/op
+*/
+func BenchmarkEvaluateDomain(b *testing.B) {
+	f, err := NewPrimeField(nttFriendlyLargePrime)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	pr := NewDensePolyRing(f).(*DensePolyRing)
+	p := randomPolynomial(f, 1, 256)
+
+	xs := make([]uint64, 1024)
+	for i := range xs {
+		xs[i] = uint64(i + 1)
+	}
+
+	b.Run("horner", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			pr.evaluateHorner(p, xs)
+		}
+	})
+
+	b.Run("forward-differences", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			pr.evaluateForwardDifferences(p, xs)
+		}
+	})
+}
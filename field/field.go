@@ -1,13 +1,22 @@
 package field
 
 import (
+	"encoding/binary"
 	"errors"
+	"io"
 	"math/big"
 	"math/bits"
 
 	"github.com/tuneinsight/lattigo/v6/ring"
 )
 
+/*
+Field is a finite field over uint64 elements.
+
+Reduction contract: Add, Sub, Mul, Neg and Inverse expect reduced inputs (< Modulus) for speed,
+and return garbage (or panic) otherwise. Reduce, Equals and Pow accept any uint64.
+Reduce inputs of unknown origin first, or use the PrimeField's AddReduced, SubReduced and MulReduced.
+*/
 type Field interface {
 	Equals(a, b uint64) bool
 	Add(a, b uint64) uint64
@@ -29,6 +38,14 @@ type PrimeField struct {
 	prime     uint64
 	generator uint64
 	factors   []uint64
+
+	// floor(2^64 / prime), see ReduceBarrett.
+	barrett uint64
+
+	// k when prime = 2^k + 1, e.g., 65537 = 2^16 + 1, otherwise 0. See reduceFermat.
+	fermatK uint
+	// prime when fermatK != 0, otherwise 0: operands below it are reduced, thus mul can use mulFermat.
+	fermatBound uint64
 }
 
 var (
@@ -38,6 +55,9 @@ var (
 
 const maxBitUsage = 63
 
+// primitiveRoot finds a generator of the field and the prime factors of p-1, which is the costly part of NewPrimeField.
+var primitiveRoot = ring.PrimitiveRoot
+
 /*
 Assumes you are using a prime. Will not check for validity.
 */
@@ -53,7 +73,7 @@ func NewPrimeField(prime uint64) (Field, error) {
 	}
 
 	// TODO: write my own function to find a primitive root, thus dropping the dependency on lattigo altogether.
-	g, factors, err := ring.PrimitiveRoot(prime, nil)
+	g, factors, err := primitiveRoot(prime, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -61,11 +81,45 @@ func NewPrimeField(prime uint64) (Field, error) {
 	bgint := &big.Int{}
 	bgint.SetUint64(prime)
 
-	return &PrimeField{
+	// 1 < prime, thus the quotient fits in 64 bits.
+	barrett, _ := bits.Div64(1, 0, prime)
+
+	fld := &PrimeField{
 		prime:     prime,
 		generator: g,
 		factors:   factors,
-	}, nil
+		barrett:   barrett,
+		fermatK:   fermatExponent(prime),
+	}
+
+	if fld.fermatK != 0 {
+		fld.fermatBound = prime
+	}
+
+	return fld, nil
+}
+
+// fermatExponent returns k if p = 2^k + 1 for k <= 31, thus products of reduced elements fit in 64 bits, or 0.
+// Primes of this form are the Fermat primes 3, 5, 17, 257 and 65537.
+// Mersenne primes 2^k - 1 have their own field, see NewMersennePrimeField.
+func fermatExponent(p uint64) uint {
+	if p < 3 || !IsPowerOfTwo(p-1) || p-1 > 1<<31 {
+		return 0
+	}
+
+	return uint(bits.TrailingZeros64(p - 1))
+}
+
+// Clone returns a copy of f without the primitive-root search of NewPrimeField, sharing its immutable factors.
+func (f *PrimeField) Clone() Field {
+	cpy := *f
+
+	return &cpy
+}
+
+// SameField reports whether a and b are fields of the same order, thus their elements are interchangeable.
+func SameField(a, b Field) bool {
+	return a.Modulus() == b.Modulus()
 }
 
 var (
@@ -99,6 +153,85 @@ func (f *PrimeField) GetRootOfUnity(n uint64) (uint64, error) {
 
 }
 
+// Order returns the multiplicative order of a, i.e., the smallest d > 0 with a^d = 1, or 0 for a = 0.
+// It divides p-1, thus starting from p-1, every prime factor q is removed while a^(d/q) = 1.
+func (f *PrimeField) Order(a uint64) uint64 {
+	a = f.Reduce(a)
+	if a == 0 {
+		return 0
+	}
+
+	order := f.prime - 1
+	for _, q := range f.factors {
+		for order%q == 0 && f.Pow(a, order/q) == 1 {
+			order /= q
+		}
+	}
+
+	return order
+}
+
+// SubgroupGenerator returns a generator of the multiplicative subgroup of the given order,
+// which must divide p-1. Unlike GetRootOfUnity, the order needn't be a power of two.
+func (f *PrimeField) SubgroupGenerator(order uint64) (uint64, error) {
+	if order == 0 || (f.prime-1)%order != 0 {
+		return 0, errNotDivisible
+	}
+
+	return f.Pow(f.generator, (f.prime-1)/order), nil
+}
+
+// nttPrimes are primes p = c*2^e + 1 with a large e, in ascending order.
+// Each supports NTTs of size up to 2^e: 2^16, 2^20, 2^26, 2^27 and 2^57 respectively.
+var nttPrimes = []uint64{
+	65537,               // 2^16 + 1.
+	7340033,             // 7*2^20 + 1.
+	469762049,           // 7*2^26 + 1.
+	2013265921,          // 15*2^27 + 1.
+	4179340454199820289, // 29*2^57 + 1.
+}
+
+var (
+	ErrNoSuggestedPrime = errors.New("no curated NTT-friendly prime supports the requested size")
+	errNonPositiveSize  = errors.New("size must be positive")
+)
+
+// SuggestPrime returns the smallest of a curated list of NTT-friendly primes whose p-1 is divisible by nextPow2(n),
+// thus NewPrimeField(p).GetRootOfUnity(nextPow2(n)) succeeds and p > n, so a code of length n can use an NttEvaluator.
+// Symbols (data elements) must be smaller than p: callers needing larger symbols may pick a larger NTT-friendly prime.
+func SuggestPrime(n int) (uint64, error) {
+	if n < 1 {
+		return 0, errNonPositiveSize
+	}
+
+	size := uint64(1) << bits.Len64(uint64(n-1)) // nextPow2(n), without overflowing on huge n.
+	for _, p := range nttPrimes {
+		if (p-1)%size == 0 {
+			return p, nil
+		}
+	}
+
+	return 0, ErrNoSuggestedPrime
+}
+
+// RandomElement returns a uniformly random element of [0, p), drawing from src, e.g., crypto/rand.Reader.
+// It rejection-samples bits.Len64(p)-bit integers, thus it reads 8 bytes at a time, less than twice on average,
+// and unlike rand.Uint64() % p isn't biased towards small values.
+func (f *PrimeField) RandomElement(src io.Reader) (uint64, error) {
+	mask := uint64(1)<<bits.Len64(f.prime) - 1
+
+	var buf [8]byte
+	for {
+		if _, err := io.ReadFull(src, buf[:]); err != nil {
+			return 0, err
+		}
+
+		if v := binary.LittleEndian.Uint64(buf[:]) & mask; v < f.prime {
+			return v, nil
+		}
+	}
+}
+
 func (f *PrimeField) ElemSlice(vals []uint64) []uint64 {
 	mod := f.prime
 	for i, v := range vals {
@@ -130,32 +263,108 @@ func (f *PrimeField) Reduce(val uint64) uint64 {
 		return val
 	}
 
+	if f.fermatK != 0 {
+		return f.reduceFermat(val)
+	}
+
 	return val % f.prime
 }
 
+/*
+reduceFermat reduces val modulo p = 2^k + 1 with shifts and adds instead of a division.
+Since 2^(2k) = 1 (mod p), the bits above 2k fold onto the low 2k bits, as for the Mersenne number 2^(2k) - 1,
+a multiple of p. Then val = hi*2^k + lo with hi, lo < 2^k, and 2^k = -1 (mod p), thus val = lo - hi (mod p),
+where -2^k < lo - hi < 2^k needs adding p at most once. Branch-free, besides the folding of large values.
+*/
+func (f *PrimeField) reduceFermat(val uint64) uint64 {
+	k := f.fermatK
+
+	for val>>(2*k) != 0 {
+		val = val&(1<<(2*k)-1) + val>>(2*k)
+	}
+
+	r, borrow := bits.Sub64(val&(1<<k-1), val>>k, 0)
+
+	return r + f.prime&-borrow
+}
+
+// ReduceBarrett is Reduce without a hardware division, for hot loops reducing many values:
+// q = floor(val * floor(2^64/p) / 2^64) underestimates val / p by at most one, thus val - q*p < 2p
+// needs a single correction.
+func (f *PrimeField) ReduceBarrett(val uint64) uint64 {
+	q, _ := bits.Mul64(val, f.barrett)
+
+	r := val - q*f.prime
+	if r >= f.prime {
+		r -= f.prime
+	}
+
+	return r
+}
+
+// reduceBarrettLazy is ReduceBarrett, skipping already reduced values.
+func (f *PrimeField) reduceBarrettLazy(val uint64) uint64 {
+	if val < f.prime {
+		return val
+	}
+
+	return f.ReduceBarrett(val)
+}
+
 func (f *PrimeField) Add(a, b uint64) uint64 {
 	if a == 0 {
 		// used for Elem{}, or Elem{0,nilField}.
 		return b
 	}
 
-	tmp := a + b // can't overflow since adding two integers smaller than 2^63.
-	if tmp >= f.prime {
+	// reduced operands are smaller than p < 2^63, thus can't overflow. Still, primes near 2^63 leave
+	// little slack for unreduced ones, so on a carry subtract p from the full 65-bit sum.
+	tmp, carry := bits.Add64(a, b, 0)
+	if carry != 0 || tmp >= f.prime {
 		tmp -= f.prime
 	}
 
 	return tmp
 }
 
+// addBranchless is Add for reduced operands, selecting the result instead of branching on it.
+// It backs ConstantTimeField.Add, and pays off in loops whose carries are unpredictable.
+func (f *PrimeField) addBranchless(a, b uint64) uint64 {
+	sum := a + b // can't overflow, see Add.
+	diff, borrow := bits.Sub64(sum, f.prime, 0)
+
+	// borrow is 1 iff sum < p.
+	return ctSelect(-borrow, sum, diff)
+}
+
 // Mul returns e * b (mod field prime).
 func (f *PrimeField) Mul(a, b uint64) uint64 {
 	if a == 0 || b == 0 {
 		return 0
 	}
 
+	return f.mul(a, b)
+}
+
+// mul is Mul without the zero shortcut, using mulFermat when possible.
+// Unreduced operands take the generic path, which reduces any product, as Mul always has.
+func (f *PrimeField) mul(a, b uint64) uint64 {
+	if max(a, b) < f.fermatBound {
+		return f.mulFermat(a, b)
+	}
+
 	return fieldMul(a, b, f.prime)
 }
 
+// mulFermat is reduceFermat of a*b <= 2^(2k), for reduced a, b <= 2^k, which needs no folding:
+// a*b = hi*2^k + lo with hi <= 2^k and lo < 2^k.
+func (f *PrimeField) mulFermat(a, b uint64) uint64 {
+	t := a * b
+	r, borrow := bits.Sub64(t&(1<<f.fermatK-1), t>>f.fermatK, 0)
+
+	return r + f.prime&-borrow
+}
+
 func fieldMul(a, b uint64, mod uint64) uint64 {
 	hi, lo := bits.Mul64(a, b)
 	_, rem := bits.Div64(hi, lo, mod)
@@ -166,21 +375,47 @@ func fieldMul(a, b uint64, mod uint64) uint64 {
 // https://en.wikipedia.org/wiki/Exponentiation_by_squaring
 func (f *PrimeField) Pow(base, exp uint64) uint64 {
 	mod := f.prime
+	base = f.Reduce(base)
 
 	x := uint64(1)
 	for exp > 0 {
 		if exp%2 == 1 { // If exponent is odd, multiply base with x
-			x = fieldMul(x, base, mod)
+			x = f.mul(x, base)
 			// x = x.Mul(base).Mod(mod)
 		}
 
-		base = fieldMul(base, base, mod) // Square the base
-		exp /= 2                         // Halve the exponent
+		base = f.mul(base, base) // Square the base
+		exp /= 2                 // Halve the exponent
 	}
 
 	return x % mod
 }
 
+// PowerTable returns base^0, base^1, ..., base^maxExp, computed iteratively.
+// Use it with PowFromTable when raising the same base to many exponents.
+func (f *PrimeField) PowerTable(base uint64, maxExp int) []uint64 {
+	table := make([]uint64, maxExp+1)
+
+	base = f.Reduce(base)
+	x := f.Reduce(1)
+	for i := range table {
+		table[i] = x
+		x = f.mul(x, base)
+	}
+
+	return table
+}
+
+// PowFromTable returns base^exp, where table = PowerTable(base, maxExp).
+// Exponents beyond maxExp fall back to Pow, which requires maxExp >= 1.
+func (f *PrimeField) PowFromTable(table []uint64, exp uint64) uint64 {
+	if exp < uint64(len(table)) {
+		return table[exp]
+	}
+
+	return f.Pow(table[1], exp)
+}
+
 func (f *PrimeField) Inverse(e uint64) uint64 {
 	// Fermat's little theorem: a^(p) = a (mod p)
 	// thus:
@@ -209,6 +444,21 @@ func (f *PrimeField) Sub(a, b uint64) uint64 {
 	return a - b
 }
 
+// AddReduced is Add for inputs that may not be reduced.
+func (f *PrimeField) AddReduced(a, b uint64) uint64 {
+	return f.Add(f.Reduce(a), f.Reduce(b))
+}
+
+// SubReduced is Sub for inputs that may not be reduced.
+func (f *PrimeField) SubReduced(a, b uint64) uint64 {
+	return f.Sub(f.Reduce(a), f.Reduce(b))
+}
+
+// MulReduced is Mul for inputs that may not be reduced.
+func (f *PrimeField) MulReduced(a, b uint64) uint64 {
+	return f.Mul(f.Reduce(a), f.Reduce(b))
+}
+
 func (f *PrimeField) Equals(a, b uint64) bool {
 	mod := f.prime
 	return (a % mod) == (b % mod)
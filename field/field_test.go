@@ -1,7 +1,12 @@
 package field
 
 import (
+	"bytes"
+	"io"
+	"math"
 	"math/big"
+	"math/bits"
+	"math/rand"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -163,6 +168,203 @@ func BenchmarkPowMod(b *testing.B) {
 
 }
 
+func TestClone(t *testing.T) {
+	a := assert.New(t)
+
+	searches := 0
+	orig := primitiveRoot
+	primitiveRoot = func(q uint64, factors []uint64) (uint64, []uint64, error) {
+		searches++
+
+		return orig(q, factors)
+	}
+	t.Cleanup(func() { primitiveRoot = orig })
+
+	f, err := NewPrimeField(nttFriendlyLargePrime)
+	a.NoError(err)
+
+	ct, err := NewConstantTimeField(65537)
+	a.NoError(err)
+
+	m, err := NewMersennePrimeField(31)
+	a.NoError(err)
+	a.Equal(3, searches)
+
+	for _, fld := range []Field{f, ct, m} {
+		cpy := fld.(interface{ Clone() Field }).Clone()
+		a.NotSame(fld, cpy)
+		a.IsType(fld, cpy)
+		a.True(SameField(fld, cpy))
+
+		a.Equal(fld.Generator(), cpy.Generator())
+		a.Equal(fld.Factors(), cpy.Factors())
+
+		for _, n := range []uint64{2, 4, 1 << 10, 1 << 32, 1 << 33} {
+			r1, err1 := fld.GetRootOfUnity(n)
+			r2, err2 := cpy.GetRootOfUnity(n)
+			a.Equal(r1, r2)
+			a.Equal(err1, err2)
+		}
+	}
+
+	a.Equal(3, searches) // Clone doesn't search again.
+
+	other, err := NewPrimeField(157)
+	a.NoError(err)
+	a.False(SameField(f, other))
+	a.True(SameField(ct, ct.(*ConstantTimeField).PrimeField))
+}
+
+func TestSuggestPrime(t *testing.T) {
+	a := assert.New(t)
+
+	for _, n := range []int{1, 2, 3, 16, 100, 1 << 16, 1<<16 + 1, 1 << 20, 1<<26 + 5, 1 << 27, 1<<30 + 1} {
+		p, err := SuggestPrime(n)
+		a.NoError(err)
+		a.Greater(p, uint64(n))
+
+		f, err := NewPrimeField(p)
+		a.NoError(err)
+
+		if n > 1 {
+			_, err = f.GetRootOfUnity(uint64(nextPow2(n)))
+			a.NoError(err, "n=%d p=%d", n, p)
+		}
+	}
+
+	p, err := SuggestPrime(1000)
+	a.NoError(err)
+	a.Equal(uint64(65537), p) // the smallest fitting one.
+
+	p, err = SuggestPrime(1<<16 + 1)
+	a.NoError(err)
+	a.Equal(uint64(7340033), p)
+
+	_, err = SuggestPrime(1<<57 + 1)
+	a.ErrorIs(err, ErrNoSuggestedPrime)
+
+	_, err = SuggestPrime(math.MaxInt)
+	a.ErrorIs(err, ErrNoSuggestedPrime)
+
+	_, err = SuggestPrime(0)
+	a.Error(err)
+}
+
+func TestPowerTable(t *testing.T) {
+	a := assert.New(t)
+
+	for _, prime := range []uint64{157, 65537, 9191248642791733759} {
+		f, err := NewPrimeField(prime)
+		a.NoError(err)
+
+		fp := f.(*PrimeField)
+
+		for _, base := range []uint64{0, 1, 3, fp.Generator(), prime + 5} {
+			table := fp.PowerTable(base, 100)
+			a.Len(table, 101)
+
+			for exp := uint64(0); exp < 150; exp++ {
+				a.Equal(f.Pow(f.Reduce(base), exp), fp.PowFromTable(table, exp), "p=%d base=%d exp=%d", prime, base, exp)
+			}
+		}
+	}
+}
+
+/*
+BenchmarkPowerTable/PowerTable         	   26415	     45023 ns/op
+BenchmarkPowerTable/Pow                	    2652	    456376 ns/op
+*/
+func BenchmarkPowerTable(b *testing.B) {
+	f, err := NewPrimeField(65537)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	fp := f.(*PrimeField)
+	g := fp.Generator()
+
+	const n = 4096
+
+	b.Run("PowerTable", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			fp.PowerTable(g, n-1)
+		}
+	})
+
+	b.Run("Pow", func(b *testing.B) {
+		table := make([]uint64, n)
+		for i := 0; i < b.N; i++ {
+			for j := range table {
+				table[j] = fp.Pow(g, uint64(j))
+			}
+		}
+	})
+}
+
+func FuzzReduceBarrett(f *testing.F) {
+	f.Add(uint64(0))
+	f.Add(uint64(largePrime - 1))
+	f.Add(uint64(largePrime))
+	f.Add(uint64(2*largePrime - 1))
+	f.Add(uint64(1<<64 - 1))
+
+	var fields []*PrimeField
+	for _, prime := range []uint64{3, 157, 65537, nttFriendlyLargePrime, largePrime} {
+		fld, err := NewPrimeField(prime)
+		if err != nil {
+			f.Fatal(err)
+		}
+
+		fields = append(fields, fld.(*PrimeField))
+	}
+
+	f.Fuzz(func(t *testing.T, val uint64) {
+		for _, fp := range fields {
+			if got, want := fp.ReduceBarrett(val), val%fp.Modulus(); got != want {
+				t.Fatalf("p=%d: ReduceBarrett(%d) = %d, want %d", fp.Modulus(), val, got, want)
+			}
+		}
+	})
+}
+
+/*
+BenchmarkReduceBarrett/Reduce         	  164359	     14654 ns/op
+BenchmarkReduceBarrett/ReduceBarrett  	  229969	     10256 ns/op
+*/
+func BenchmarkReduceBarrett(b *testing.B) {
+	f, err := NewPrimeField(largePrime)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	fp := f.(*PrimeField)
+
+	xs := make([]uint64, 4096)
+	for i := range xs {
+		xs[i] = uint64(i)*0x9e3779b97f4a7c15 | 1<<63 // unreduced.
+	}
+
+	var sink uint64
+
+	b.Run("Reduce", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, x := range xs {
+				sink += fp.Reduce(x)
+			}
+		}
+	})
+
+	b.Run("ReduceBarrett", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, x := range xs {
+				sink += fp.ReduceBarrett(x)
+			}
+		}
+	})
+
+	_ = sink
+}
+
 func BenchmarkMulModBig(b *testing.B) {
 	f, err := NewPrimeField(9191248642791733759)
 	if err != nil {
@@ -209,6 +411,134 @@ func FuzzSub_Simple(fz *testing.F) {
 	})
 }
 
+func FuzzAddNearMaxPrime(f *testing.F) {
+	const p = uint64(largePrime) // ~2^63.
+
+	f.Add(uint64(0), uint64(0))
+	f.Add(p-1, p-1)
+	f.Add(p-1, uint64(1))
+	f.Add(p/2, p/2+1)
+	f.Add(uint64(1<<64-1), p-1) // wraps without the carry.
+
+	pf, err := NewPrimeField(p)
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	mod := new(big.Int).SetUint64(p)
+	ref := func(a, b uint64) uint64 {
+		z := new(big.Int).Add(new(big.Int).SetUint64(a), new(big.Int).SetUint64(b))
+
+		return z.Mod(z, mod).Uint64()
+	}
+
+	f.Fuzz(func(t *testing.T, aSeed, bSeed uint64) {
+		a, b := pf.Reduce(aSeed), pf.Reduce(bSeed)
+
+		if got, want := pf.Add(a, b), ref(a, b); got != want {
+			t.Fatalf("Add(%d, %d) = %d, want %d", a, b, got, want)
+		}
+
+		// an unreduced operand may push the sum past 2^64; the result isn't reduced, but is still congruent.
+		if aSeed == 0 {
+			return // the a == 0 shortcut returns b as is.
+		}
+
+		if got, want := pf.Reduce(pf.Add(aSeed, b)), ref(aSeed, b); got != want {
+			t.Fatalf("Add(%d, %d) = %d (mod p), want %d", aSeed, b, got, want)
+		}
+	})
+}
+
+func FuzzUnreducedInputs(f *testing.F) {
+	f.Add(uint64(0), uint64(0), uint64(0))
+	f.Add(uint64(9191248642791733759), uint64(9191248642791733760), uint64(2))
+	f.Add(uint64(1<<64-1), uint64(1<<63), uint64(1<<64-1))
+	f.Add(uint64(65537), uint64(131074), uint64(65536))
+
+	var fields []*PrimeField
+	for _, prime := range []uint64{65537, 9191248642791733759} {
+		fld, err := NewPrimeField(prime)
+		if err != nil {
+			f.Fatal(err)
+		}
+
+		fields = append(fields, fld.(*PrimeField))
+	}
+
+	f.Fuzz(func(t *testing.T, a, b, exp uint64) {
+		for _, fp := range fields {
+			prime := fp.Modulus()
+			mod := new(big.Int).SetUint64(prime)
+
+			ref := func(op func(z, x, y *big.Int) *big.Int) uint64 {
+				z := op(new(big.Int), new(big.Int).SetUint64(a), new(big.Int).SetUint64(b))
+
+				return z.Mod(z, mod).Uint64()
+			}
+
+			if got, want := fp.AddReduced(a, b), ref((*big.Int).Add); got != want {
+				t.Fatalf("p=%d: AddReduced(%d, %d) = %d, want %d", prime, a, b, got, want)
+			}
+
+			if got, want := fp.SubReduced(a, b), ref((*big.Int).Sub); got != want {
+				t.Fatalf("p=%d: SubReduced(%d, %d) = %d, want %d", prime, a, b, got, want)
+			}
+
+			if got, want := fp.MulReduced(a, b), ref((*big.Int).Mul); got != want {
+				t.Fatalf("p=%d: MulReduced(%d, %d) = %d, want %d", prime, a, b, got, want)
+			}
+
+			powRef := new(big.Int).Exp(new(big.Int).SetUint64(a), new(big.Int).SetUint64(exp), mod).Uint64()
+			if got := fp.Pow(a, exp); got != powRef {
+				t.Fatalf("p=%d: Pow(%d, %d) = %d, want %d", prime, a, exp, got, powRef)
+			}
+
+			// Equals agrees with the reduced ops.
+			if fp.Equals(a, b) != (fp.SubReduced(a, b) == 0) {
+				t.Fatalf("p=%d: Equals(%d, %d) disagrees with SubReduced", prime, a, b)
+			}
+		}
+	})
+}
+
+func TestRandomElement(t *testing.T) {
+	a := assert.New(t)
+
+	const p = 157
+
+	f, err := NewPrimeField(p)
+	a.NoError(err)
+
+	pf := f.(*PrimeField)
+	src := rand.New(rand.NewSource(1))
+
+	const samples = 157 * 200
+
+	counts := make([]int, p)
+	for i := 0; i < samples; i++ {
+		v, err := pf.RandomElement(src)
+		a.NoError(err)
+		a.Less(v, uint64(p))
+
+		counts[v]++
+	}
+
+	// chi-square with p-1 = 156 degrees of freedom: mean 156, stddev ~17.7.
+	// 250 is over 5 standard deviations away, while a bias towards small values would blow far past it.
+	expected := float64(samples) / p
+	chi2 := 0.0
+	for _, c := range counts {
+		chi2 += (float64(c) - expected) * (float64(c) - expected) / expected
+	}
+
+	a.Less(chi2, 250.0)
+
+	// read errors are returned.
+	_, err = pf.RandomElement(bytes.NewReader([]byte{1, 2, 3}))
+	a.ErrorIs(err, io.ErrUnexpectedEOF)
+}
+
 func TestRootsOfUnityGeneration(t *testing.T) {
 	a := assert.New(t)
 
@@ -223,6 +553,46 @@ func TestRootsOfUnityGeneration(t *testing.T) {
 	}
 }
 
+func TestOrderAndSubgroupGenerator(t *testing.T) {
+	a := assert.New(t)
+
+	for _, prime := range []uint64{157, 65537, largePrime} {
+		fld, err := NewPrimeField(prime)
+		a.NoError(err)
+
+		f := fld.(*PrimeField)
+		a.Equal(prime-1, f.Order(f.Generator()))
+		a.Equal(uint64(1), f.Order(1))
+		a.Equal(uint64(2), f.Order(prime-1))
+		a.Equal(uint64(0), f.Order(0))
+	}
+
+	fld, err := NewPrimeField(157) // 156 = 2^2 * 3 * 13.
+	a.NoError(err)
+
+	f := fld.(*PrimeField)
+	for order := uint64(1); order <= 156; order++ {
+		g, err := f.SubgroupGenerator(order)
+		if 156%order != 0 {
+			a.ErrorIs(err, errNotDivisible)
+			continue
+		}
+
+		a.NoError(err)
+		a.Equal(order, f.Order(g))
+		a.True(isRootOfUnityOfOrderN(f, g, order), "order=%d", order)
+	}
+
+	_, err = f.SubgroupGenerator(0)
+	a.ErrorIs(err, errNotDivisible)
+
+	// the order of every element divides p-1.
+	for x := uint64(1); x < 157; x++ {
+		a.Equal(uint64(1), f.Pow(x, f.Order(x)))
+		a.Zero(156 % f.Order(x))
+	}
+}
+
 func isRootOfUnityOfOrderN(field Field, root, n uint64) bool {
 	mp := make(map[uint64]int)
 	for i := uint64(0); i < n; i++ {
@@ -232,3 +602,98 @@ func isRootOfUnityOfOrderN(field Field, root, n uint64) bool {
 	// Check if all powers are distinct
 	return len(mp) == int(n) && mp[1] == 1
 }
+
+func FuzzReduceFermat(f *testing.F) {
+	f.Add(uint64(0), uint64(0))
+	f.Add(uint64(65536), uint64(65536))
+	f.Add(uint64(65537), uint64(1))
+	f.Add(uint64(1<<32), uint64(1<<64-1))
+	f.Add(uint64(1<<64-1), uint64(1<<63))
+	f.Add(uint64(70000), uint64(70000))
+	f.Add(uint64(1<<20), uint64(1<<20))
+
+	var fields []*PrimeField
+	for _, prime := range []uint64{3, 5, 17, 257, 65537} {
+		fld, err := NewPrimeField(prime)
+		if err != nil {
+			f.Fatal(err)
+		}
+
+		fp := fld.(*PrimeField)
+		if fp.fermatK == 0 {
+			f.Fatalf("p=%d: expected the Fermat form", prime)
+		}
+
+		fields = append(fields, fp)
+	}
+
+	f.Fuzz(func(t *testing.T, val, other uint64) {
+		for _, fp := range fields {
+			p := fp.Modulus()
+			if got, want := fp.Reduce(val), val%p; got != want {
+				t.Fatalf("p=%d: Reduce(%d) = %d, want %d", p, val, got, want)
+			}
+
+			a, b := val%p, other%p
+			if got, want := fp.Mul(a, b), fieldMul(a, b, p); got != want {
+				t.Fatalf("p=%d: Mul(%d, %d) = %d, want %d", p, a, b, got, want)
+			}
+
+			// unreduced operands, as long as their product fits 64 bits, see fieldMul.
+			for _, ops := range [][2]uint64{{val, other}, {val >> 32, other >> 32}} {
+				if hi, _ := bits.Mul64(ops[0], ops[1]); hi != 0 {
+					continue
+				}
+
+				if got, want := fp.Mul(ops[0], ops[1]), fieldMul(ops[0], ops[1], p); got != want {
+					t.Fatalf("p=%d: Mul(%d, %d) = %d, want %d", p, ops[0], ops[1], got, want)
+				}
+			}
+		}
+	})
+}
+
+/*
+BenchmarkMulFermat/generic         	   37622	     32586 ns/op
+BenchmarkMulFermat/fermat          	   74626	     16365 ns/op
+*/
+func BenchmarkMulFermat(b *testing.B) {
+	f, err := NewPrimeField(65537)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	fp := f.(*PrimeField)
+
+	xs := make([]uint64, 4096)
+	for i := range xs {
+		xs[i] = max(fp.Reduce(uint64(i)*0x9e3779b97f4a7c15), 1)
+	}
+
+	var sink uint64
+
+	// the division-based Mul, as before the Fermat form was detected.
+	b.Run("generic", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			acc := uint64(3)
+			for _, x := range xs {
+				acc = fieldMul(acc, x, 65537)
+			}
+
+			sink += acc
+		}
+	})
+
+	b.Run("fermat", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			acc := uint64(3)
+			for _, x := range xs {
+				acc = fp.Mul(acc, x)
+			}
+
+			sink += acc
+		}
+	})
+
+	_ = sink
+}
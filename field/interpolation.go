@@ -1,6 +1,13 @@
 package field
 
-import "errors"
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"sync"
+)
 
 type Interpolator struct {
 	pr PolyRing
@@ -31,8 +38,8 @@ func (intr *Interpolator) Interpolate(xs, ys []uint64) (*Polynomial, error) {
 	// Creating m(x) = \prod_{0\le i \le n} m_i(x) = \prod_{0\le i \le n} (x - x_i)
 	miSlice := intr.createMiSlice(xs)
 
-	// O(n^2) total cost, since we are multiplying n polynomials of degree 1.
-	m := PolyProduct(intr.pr, miSlice)
+	// O(n log^2 n) with NTT-friendly fields, O(n^2) otherwise.
+	m := PolyProductTree(intr.pr, miSlice)
 
 	liSlice := make([]Polynomial, len(xs))
 
@@ -58,7 +65,237 @@ func (intr *Interpolator) Interpolate(xs, ys []uint64) (*Polynomial, error) {
 	return intr.similarDegreePolySum(liSlice), nil
 }
 
-// PolyProduct multiplies a slice of polynomials.
+// ErrDegreeExceeded is returned by InterpolateBounded when the interpolant's degree exceeds the bound.
+var ErrDegreeExceeded = errors.New("interpolant degree exceeds the bound")
+
+// InterpolateBounded is Interpolate for points expected on a polynomial of degree at most maxDegree,
+// e.g., k-1 for the message polynomial of an erasure-only decoder. It returns ErrDegreeExceeded otherwise.
+func (intr *Interpolator) InterpolateBounded(xs, ys []uint64, maxDegree int) (*Polynomial, error) {
+	p, err := intr.Interpolate(xs, ys)
+	if err != nil {
+		return nil, err
+	}
+
+	if deg := p.Degree(); deg > maxDegree {
+		return nil, fmt.Errorf("%w: degree %d > %d", ErrDegreeExceeded, deg, maxDegree)
+	}
+
+	return p, nil
+}
+
+// InterpScratch holds the buffers InterpolateInto reuses across calls.
+// The zero value is ready to use. Not safe for concurrent use.
+type InterpScratch struct {
+	m    []uint64 // m(x) = \prod (x - x_i)
+	q    []uint64 // q_i(x) = m(x) / (x - x_i)
+	out  Polynomial
+	seen map[uint64]struct{}
+}
+
+// InterpolateInto computes the same polynomial as Interpolate, but works within the buffers of scratch,
+// thus repeated calls of similar sizes don't allocate.
+// The returned polynomial is owned by scratch and is overwritten by the next call; Copy it to retain it.
+func (intr *Interpolator) InterpolateInto(xs, ys []uint64, scratch *InterpScratch) (*Polynomial, error) {
+	if len(xs) != len(ys) {
+		return nil, errPointsSizeMismatch
+	}
+
+	if scratch.seen == nil {
+		scratch.seen = make(map[uint64]struct{}, len(xs))
+	}
+
+	clear(scratch.seen)
+	for _, x := range xs {
+		if _, ok := scratch.seen[x]; ok {
+			return nil, newDuplicatePointError(xs, x)
+		}
+
+		scratch.seen[x] = struct{}{}
+	}
+
+	f := intr.pr.GetField()
+	n := len(xs)
+
+	scratch.m = resize(scratch.m, n+1)
+	scratch.q = resize(scratch.q, n)
+	scratch.out.inner = resize(scratch.out.inner, max(n, 1))
+	scratch.out.f, scratch.out.isNTT = f, false
+
+	m, q, out := scratch.m, scratch.q, scratch.out.inner
+	clear(out)
+
+	productMonicNegRootsInto(f, xs, m)
+
+	for i, x := range xs {
+		x = f.Reduce(x)
+
+		// synthetic division of m by (x - x_i): q_{j-1} = m_j + x_i * q_j.
+		q[n-1] = m[n]
+		for j := n - 1; j > 0; j-- {
+			q[j-1] = f.Add(m[j], f.Mul(x, q[j]))
+		}
+
+		// s = q_i(x_i) = \prod_{j \ne i} (x_i - x_j)
+		s := uint64(0)
+		for j := n - 1; j >= 0; j-- {
+			s = f.Add(q[j], f.Mul(x, s))
+		}
+
+		coeff := f.Mul(f.Reduce(ys[i]), f.Inverse(s))
+		if coeff == 0 {
+			continue
+		}
+
+		for j := range q {
+			out[j] = f.Add(out[j], f.Mul(coeff, q[j]))
+		}
+	}
+
+	return &scratch.out, nil
+}
+
+/*
+DomainInterpolator interpolates over a fixed set of points, e.g., the evaluation points of a code.
+The denominators of the Lagrange basis, s_i = \prod_{j \ne i} (x_i - x_j), depend only on the points,
+thus they are computed and inverted once, along with m(x) = \prod (x - x_i), instead of on every call:
+each Interpolate is then a synthetic division and an accumulation per point.
+It is immutable, thus safe for concurrent use.
+*/
+type DomainInterpolator struct {
+	f       Field
+	m       []uint64 // m(x) = \prod (x - x_i)
+	xs      []uint64 // reduced.
+	weights []uint64 // 1 / s_i
+}
+
+// NewDomainInterpolator precomputes the interpolation over xs, in O(n^2).
+// It returns a *DuplicatePointError if xs has duplicates.
+func NewDomainInterpolator(pr PolyRing, xs []uint64) (*DomainInterpolator, error) {
+	if err := validateInterpolationPoints(xs, xs); err != nil {
+		return nil, err
+	}
+
+	f := pr.GetField()
+	n := len(xs)
+
+	d := &DomainInterpolator{
+		f:       f,
+		m:       make([]uint64, n+1),
+		xs:      make([]uint64, n),
+		weights: make([]uint64, n),
+	}
+
+	for i, x := range xs {
+		d.xs[i] = f.Reduce(x)
+	}
+
+	productMonicNegRootsInto(f, d.xs, d.m)
+
+	// s_i = m'(x_i), since x_i is a simple root of m.
+	deriv := &Polynomial{}
+	pr.Derivative(NewPolynomial(f, d.m, false), deriv)
+
+	for i, x := range d.xs {
+		s := pr.Evaluate(deriv, x)
+		if s == 0 {
+			return nil, errNonUniqueXs // e.g., x and x+p.
+		}
+
+		d.weights[i] = s
+	}
+
+	batchInvert(f, d.weights)
+
+	return d, nil
+}
+
+// batchInvert inverts the non-zero xs in place with a single field inversion (Montgomery's trick).
+func batchInvert(f Field, xs []uint64) {
+	if len(xs) == 0 {
+		return
+	}
+
+	prefix := make([]uint64, len(xs))
+
+	acc := uint64(1)
+	for i, x := range xs {
+		prefix[i] = acc
+		acc = f.Mul(acc, x)
+	}
+
+	inv := f.Inverse(acc)
+	for i := len(xs) - 1; i >= 0; i-- {
+		xs[i], inv = f.Mul(inv, prefix[i]), f.Mul(inv, xs[i])
+	}
+}
+
+// Points returns the number of interpolation points.
+func (d *DomainInterpolator) Points() int {
+	return len(d.xs)
+}
+
+// Interpolate returns the polynomial p of degree < n such that p(x_i) = ys[i].
+func (d *DomainInterpolator) Interpolate(ys []uint64) (*Polynomial, error) {
+	if len(ys) != len(d.xs) {
+		return nil, errPointsSizeMismatch
+	}
+
+	out := make([]uint64, max(len(d.xs), 1))
+	d.interpolateInto(ys, make([]uint64, len(d.xs)), out)
+
+	return NewPolynomial(d.f, out, false), nil
+}
+
+// InterpolateInto is Interpolate within the buffers of scratch, see Interpolator.InterpolateInto.
+// The returned polynomial is owned by scratch and is overwritten by the next call; Copy it to retain it.
+func (d *DomainInterpolator) InterpolateInto(ys []uint64, scratch *InterpScratch) (*Polynomial, error) {
+	if len(ys) != len(d.xs) {
+		return nil, errPointsSizeMismatch
+	}
+
+	scratch.q = resize(scratch.q, len(d.xs))
+	scratch.out.inner = resize(scratch.out.inner, max(len(d.xs), 1))
+	scratch.out.f, scratch.out.isNTT = d.f, false
+
+	d.interpolateInto(ys, scratch.q, scratch.out.inner)
+
+	return &scratch.out, nil
+}
+
+// interpolateInto sets out = \sum_i ys[i] / s_i * m(x) / (x - x_i), using q for the quotients.
+func (d *DomainInterpolator) interpolateInto(ys, q, out []uint64) {
+	f, m, n := d.f, d.m, len(d.xs)
+	clear(out)
+
+	for i, x := range d.xs {
+		coeff := f.Mul(f.Reduce(ys[i]), d.weights[i])
+		if coeff == 0 {
+			continue
+		}
+
+		// synthetic division of m by (x - x_i): q_{j-1} = m_j + x_i * q_j.
+		q[n-1] = m[n]
+		for j := n - 1; j > 0; j-- {
+			q[j-1] = f.Add(m[j], f.Mul(x, q[j]))
+		}
+
+		for j := range q {
+			out[j] = f.Add(out[j], f.Mul(coeff, q[j]))
+		}
+	}
+}
+
+// resize returns buf with length n, reallocating only if its capacity is too small.
+func resize(buf []uint64, n int) []uint64 {
+	if cap(buf) < n {
+		return make([]uint64, n)
+	}
+
+	return buf[:n]
+}
+
+// PolyProduct multiplies a slice of polynomials, left to right.
+// O(n^2) for n polynomials of degree 1, see PolyProductTree.
 func PolyProduct(pr PolyRing, miSlice []*Polynomial) *Polynomial {
 	m := makeConstantPoly(pr.GetField(), 1)
 	for _, mi := range miSlice {
@@ -68,8 +305,119 @@ func PolyProduct(pr PolyRing, miSlice []*Polynomial) *Polynomial {
 	return m
 }
 
-// similarDegreePolySum sums polynomials of the same degree.
+// PolyProductTree multiplies a slice of polynomials pairwise in a balanced binary tree.
+// With a DensePolyRing over an NTT-friendly field, large products use NTT multiplication,
+// thus n polynomials of degree 1 cost O(n log^2 n) instead of PolyProduct's O(n^2).
+// The inputs are not modified.
+func PolyProductTree(pr PolyRing, polys []*Polynomial) *Polynomial {
+	if len(polys) == 0 {
+		return makeConstantPoly(pr.GetField(), 1)
+	}
+
+	mul := pr.MulPoly
+	if dense, ok := pr.(*DensePolyRing); ok {
+		mul = dense.mulFull
+	}
+
+	level := polys
+	for len(level) > 1 {
+		next := make([]*Polynomial, 0, (len(level)+1)/2)
+		for i := 0; i+1 < len(level); i += 2 {
+			prod := &Polynomial{f: pr.GetField()}
+			mul(level[i], level[i+1], prod)
+			next = append(next, prod)
+		}
+
+		if len(level)%2 == 1 {
+			next = append(next, level[len(level)-1])
+		}
+
+		level = next
+	}
+
+	if len(polys) == 1 {
+		return polys[0].Copy()
+	}
+
+	prod := level[0]
+	prod.removeLeadingZeroes()
+
+	return prod
+}
+
+// vanishingCacheSize bounds the number of cached vanishing polynomials; the cache is reset once full.
+const vanishingCacheSize = 64
+
+type vanishingKey struct {
+	f    Field
+	hash uint64
+}
+
+type vanishingEntry struct {
+	xs   []uint64 // sorted, to rule out hash collisions.
+	poly *Polynomial
+}
+
+var vanishingCache = struct {
+	sync.Mutex
+	entries map[vanishingKey]vanishingEntry
+}{entries: map[vanishingKey]vanishingEntry{}}
+
+/*
+VanishingPolynomial returns \prod (x - x_i), the monic polynomial whose roots are xs (with multiplicity),
+same as PolyProductMonicNegRoots, but computed with PolyProductTree, thus it scales to thousands of points.
+Results are cached per field and set of points (the order of xs doesn't matter), and callers get a copy.
+*/
+func VanishingPolynomial(pr PolyRing, xs []uint64) *Polynomial {
+	f := pr.GetField()
+
+	sorted := make([]uint64, len(xs))
+	for i, x := range xs {
+		sorted[i] = f.Reduce(x)
+	}
+
+	slices.Sort(sorted)
+
+	h := fnv.New64a()
+	buf := make([]byte, 0, 8*len(sorted))
+	for _, x := range sorted {
+		buf = binary.LittleEndian.AppendUint64(buf, x)
+	}
+
+	h.Write(buf)
+	key := vanishingKey{f: f, hash: h.Sum64()}
+
+	vanishingCache.Lock()
+	entry, ok := vanishingCache.entries[key]
+	vanishingCache.Unlock()
+
+	if ok && slices.Equal(entry.xs, sorted) {
+		return entry.poly.Copy()
+	}
+
+	poly := PolyProductTree(pr, NewInterpolator(pr).createMiSlice(sorted))
+
+	vanishingCache.Lock()
+	if len(vanishingCache.entries) >= vanishingCacheSize {
+		clear(vanishingCache.entries)
+	}
+
+	vanishingCache.entries[key] = vanishingEntry{xs: sorted, poly: poly}
+	vanishingCache.Unlock()
+
+	return poly.Copy()
+}
+
+// similarDegreePolySum sums polynomials of the same degree, whose coefficients are reduced.
 func (intr *Interpolator) similarDegreePolySum(polys []Polynomial) *Polynomial {
+	if pf, ok := intr.pr.GetField().(*PrimeField); ok {
+		return similarDegreePolySumLazy(pf, polys)
+	}
+
+	return intr.similarDegreePolySumGeneric(polys)
+}
+
+func (intr *Interpolator) similarDegreePolySumGeneric(polys []Polynomial) *Polynomial {
 	inner := make([]uint64, len(polys[0].inner))
 	fld := intr.pr.GetField()
 	for _, poly := range polys {
@@ -82,6 +430,40 @@ func (intr *Interpolator) similarDegreePolySum(polys []Polynomial) *Polynomial {
 
 }
 
+/*
+similarDegreePolySumLazy is similarDegreePolySum over a prime field with deferred reduction: the coefficients
+are plain uint64 sums, reduced once every batch polynomials rather than on each addition.
+A reduced sum is at most p-1, thus adding batch terms of at most p-1 each keeps it below
+(batch+1)*(p-1) <= 2^64 - 1, with batch = (2^64-1)/(p-1) - 1, which is at least 1 for p < 2^63.
+*/
+func similarDegreePolySumLazy(pf *PrimeField, polys []Polynomial) *Polynomial {
+	inner := make([]uint64, len(polys[0].inner))
+	batch := (^uint64(0))/(pf.prime-1) - 1
+
+	pending := uint64(0)
+	for _, poly := range polys {
+		if pending == batch {
+			for i, v := range inner {
+				inner[i] = pf.ReduceBarrett(v)
+			}
+
+			pending = 0
+		}
+
+		for i, coef := range poly.inner {
+			inner[i] += coef
+		}
+
+		pending++
+	}
+
+	for i, v := range inner {
+		inner[i] = pf.ReduceBarrett(v)
+	}
+
+	return NewPolynomial(pf, inner, false)
+}
+
 // createMiSlice creates the m_i(x) = (x - x_i) polynomials.
 func (intr *Interpolator) createMiSlice(xs []uint64) []*Polynomial {
 	miSlice := make([]*Polynomial, len(xs))
@@ -122,19 +504,45 @@ func (intr *Interpolator) mDivMi(m_, mi_ *Polynomial) *Polynomial {
 	return NewPolynomial(f, qinner, false)
 }
 
+// DuplicatePointError reports a repeated x value given to Interpolate, and every position it appears in.
+// errors.Is(err, errNonUniqueXs) holds for it.
+type DuplicatePointError struct {
+	X       uint64
+	Indices []int
+}
+
+func (e *DuplicatePointError) Error() string {
+	return fmt.Sprintf("%v: x=%d at indices %v", errNonUniqueXs, e.X, e.Indices)
+}
+
+func (e *DuplicatePointError) Unwrap() error {
+	return errNonUniqueXs
+}
+
 func validateInterpolationPoints(xs []uint64, ys []uint64) error {
 	if len(xs) != len(ys) {
 		return errPointsSizeMismatch
 	}
 
-	mapXs := make(map[uint64]struct{})
+	mapXs := make(map[uint64]struct{}, len(xs))
 	for _, x := range xs {
+		if _, ok := mapXs[x]; ok {
+			return newDuplicatePointError(xs, x)
+		}
+
 		mapXs[x] = struct{}{}
 	}
 
-	if len(mapXs) != len(xs) {
-		return errNonUniqueXs
+	return nil
+}
+
+func newDuplicatePointError(xs []uint64, x uint64) *DuplicatePointError {
+	indices := []int{}
+	for i, v := range xs {
+		if v == x {
+			indices = append(indices, i)
+		}
 	}
 
-	return nil
+	return &DuplicatePointError{X: x, Indices: indices}
 }
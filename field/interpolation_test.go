@@ -2,6 +2,7 @@ package field
 
 import (
 	"fmt"
+	"math/rand"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -156,3 +157,260 @@ func BenchmarkMDivMi(b *testing.B) {
 		}
 	})
 }
+
+func TestInterpolationDuplicatePoints(t *testing.T) {
+	a := assert.New(t)
+
+	f, err := NewPrimeField(157)
+	a.NoError(err)
+
+	intr := NewInterpolator(NewDensePolyRing(f))
+
+	xs := []uint64{1, 5, 2, 5, 3, 5}
+	ys := []uint64{1, 2, 3, 4, 5, 6}
+
+	_, err = intr.Interpolate(xs, ys)
+	a.ErrorIs(err, errNonUniqueXs)
+
+	var dupErr *DuplicatePointError
+	a.ErrorAs(err, &dupErr)
+	a.Equal(uint64(5), dupErr.X)
+	a.Equal([]int{1, 3, 5}, dupErr.Indices)
+
+	_, err = intr.Interpolate(xs, ys[:2])
+	a.ErrorIs(err, errPointsSizeMismatch)
+}
+
+func TestInterpolateBounded(t *testing.T) {
+	a := assert.New(t)
+
+	f, err := NewPrimeField(65537)
+	a.NoError(err)
+
+	pr := NewDensePolyRing(f)
+	intr := NewInterpolator(pr)
+
+	// 10 points on a polynomial of degree 3.
+	p := NewPolynomial(f, []uint64{4, 3, 2, 1}, false)
+	xs := makeRoots(10)
+	ys := make([]uint64, len(xs))
+	for i, x := range xs {
+		ys[i] = pr.Evaluate(p, x)
+	}
+
+	for _, maxDegree := range []int{3, 4, 9} {
+		q, err := intr.InterpolateBounded(xs, ys, maxDegree)
+		a.NoError(err)
+		a.True(p.Equals(q))
+	}
+
+	_, err = intr.InterpolateBounded(xs, ys, 2)
+	a.ErrorIs(err, ErrDegreeExceeded)
+
+	// moving a single point makes the interpolant of degree 9.
+	ys[5] = f.Add(ys[5], 1)
+
+	_, err = intr.InterpolateBounded(xs, ys, 3)
+	a.ErrorIs(err, ErrDegreeExceeded)
+	a.EqualError(err, "interpolant degree exceeds the bound: degree 9 > 3")
+
+	_, err = intr.InterpolateBounded(xs, ys[:3], 3)
+	a.ErrorIs(err, errPointsSizeMismatch)
+}
+
+func TestInterpolateInto(t *testing.T) {
+	a := assert.New(t)
+
+	f, err := NewPrimeField(65537)
+	a.NoError(err)
+
+	pr := NewDensePolyRing(f)
+	intr := NewInterpolator(pr)
+	scratch := &InterpScratch{}
+
+	for _, n := range []int{1, 2, 5, 16, 33, 8} { // shrinking reuses the buffers.
+		p := randomPolynomial(f, uint64(1234*n), n)
+		xs, ys := evalPolyForTest(pr, p, n, n)
+
+		expected, err := intr.Interpolate(xs, ys)
+		a.NoError(err)
+
+		got, err := intr.InterpolateInto(xs, ys, scratch)
+		a.NoError(err)
+
+		a.True(expected.Equals(got))
+		a.True(p.Equals(got))
+	}
+
+	_, err = intr.InterpolateInto([]uint64{1, 2, 1}, []uint64{1, 2, 3}, scratch)
+	a.ErrorIs(err, errNonUniqueXs)
+
+	_, err = intr.InterpolateInto([]uint64{1, 2}, []uint64{1}, scratch)
+	a.ErrorIs(err, errPointsSizeMismatch)
+}
+
+func TestDomainInterpolator(t *testing.T) {
+	a := assert.New(t)
+
+	f, err := NewPrimeField(65537)
+	a.NoError(err)
+
+	bf, err := NewBinaryField(8)
+	a.NoError(err)
+
+	for _, fld := range []Field{f, bf} {
+		pr := NewDensePolyRing(fld)
+		intr := NewInterpolator(pr)
+		scratch := &InterpScratch{}
+
+		for _, n := range []int{1, 2, 5, 16, 33} {
+			xs, _ := evalPolyForTest(pr, randomPolynomial(fld, 1, n), n, n)
+
+			d, err := NewDomainInterpolator(pr, xs)
+			a.NoError(err)
+			a.Equal(n, d.Points())
+
+			// the precomputation is reused across several words over the same points.
+			for seed := uint64(1); seed <= 3; seed++ {
+				p := randomPolynomial(fld, seed*uint64(n), n)
+				_, ys := evalPolyForTest(pr, p, n, n)
+
+				expected, err := intr.Interpolate(xs, ys)
+				a.NoError(err)
+
+				got, err := d.Interpolate(ys)
+				a.NoError(err)
+				a.True(expected.Equals(got))
+				a.True(p.Equals(got))
+
+				got, err = d.InterpolateInto(ys, scratch)
+				a.NoError(err)
+				a.True(p.Equals(got))
+			}
+		}
+	}
+
+	pr := NewDensePolyRing(f)
+
+	_, err = NewDomainInterpolator(pr, []uint64{1, 2, 1})
+	a.ErrorIs(err, errNonUniqueXs)
+
+	_, err = NewDomainInterpolator(pr, []uint64{1, 2, 65538})
+	a.ErrorIs(err, errNonUniqueXs)
+
+	d, err := NewDomainInterpolator(pr, []uint64{1, 2})
+	a.NoError(err)
+
+	_, err = d.Interpolate([]uint64{1})
+	a.ErrorIs(err, errPointsSizeMismatch)
+}
+
+func BenchmarkInterpolate(b *testing.B) {
+	f, err := NewPrimeField(65537)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	pr := NewDensePolyRing(f)
+	intr := NewInterpolator(pr)
+
+	n := 256
+	p := randomPolynomial(f, 12345, n)
+	xs, ys := evalPolyForTest(pr, p, 0, n)
+
+	b.Run("Interpolate", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := intr.Interpolate(xs, ys); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("InterpolateInto", func(b *testing.B) {
+		scratch := &InterpScratch{}
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := intr.InterpolateInto(xs, ys, scratch); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("DomainInterpolator", func(b *testing.B) {
+		d, err := NewDomainInterpolator(pr, xs)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		scratch := &InterpScratch{}
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := d.InterpolateInto(ys, scratch); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestSimilarDegreePolySum(t *testing.T) {
+	a := assert.New(t)
+	rnd := rand.New(rand.NewSource(1))
+
+	// largePrime allows a single unreduced addition, nttFriendlyLargePrime a few.
+	for _, prime := range []uint64{157, 65537, nttFriendlyLargePrime, largePrime} {
+		f, err := NewPrimeField(prime)
+		a.NoError(err)
+
+		intr := NewInterpolator(NewDensePolyRing(f))
+
+		for _, numPolys := range []int{1, 2, 3, 7, 100} {
+			polys := make([]Polynomial, numPolys)
+			for i := range polys {
+				coeffs := make([]uint64, 16)
+				for j := range coeffs {
+					coeffs[j] = rnd.Uint64() % prime
+				}
+
+				coeffs[0] = prime - 1 // the largest term.
+				polys[i] = *NewPolynomial(f, coeffs, false)
+			}
+
+			expected := intr.similarDegreePolySumGeneric(polys)
+			a.Equal(expected.ToSlice(), intr.similarDegreePolySum(polys).ToSlice(), "p=%d polys=%d", prime, numPolys)
+		}
+	}
+}
+
+/*
+BenchmarkSimilarDegreePolySum/generic    	     372	   3753874 ns/op
+BenchmarkSimilarDegreePolySum/lazy       	    1555	    651927 ns/op
+*/
+func BenchmarkSimilarDegreePolySum(b *testing.B) {
+	f, err := NewPrimeField(65537)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	intr := NewInterpolator(NewDensePolyRing(f))
+
+	// the l_i of an interpolation over 1024 points.
+	const k = 1024
+	polys := make([]Polynomial, k)
+	for i := range polys {
+		polys[i] = *randomPolynomial(f, uint64(i), k)
+	}
+
+	b.Run("generic", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			intr.similarDegreePolySumGeneric(polys)
+		}
+	})
+
+	b.Run("lazy", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			intr.similarDegreePolySum(polys)
+		}
+	})
+}
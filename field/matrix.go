@@ -0,0 +1,222 @@
+package field
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrSingularMatrix = errors.New("matrix is singular")
+	ErrMatrixShape    = errors.New("matrix dimensions don't match")
+)
+
+// Matrix is a dense row-major matrix over a Field, e.g., for building custom codes out of linear algebra.
+// Its entries are reduced field elements, and its methods don't modify their receiver or arguments.
+type Matrix struct {
+	f    Field
+	rows [][]uint64
+}
+
+// NewMatrix returns a matrix over f with a reduced copy of rows, which must be non-empty and of equal lengths.
+func NewMatrix(f Field, rows [][]uint64) (*Matrix, error) {
+	if len(rows) == 0 || len(rows[0]) == 0 {
+		return nil, fmt.Errorf("%w: matrix must have at least one row and column", ErrMatrixShape)
+	}
+
+	m := newZeroMatrix(f, len(rows), len(rows[0]))
+	for i, row := range rows {
+		if len(row) != len(rows[0]) {
+			return nil, fmt.Errorf("%w: row %d has %d columns, row 0 has %d", ErrMatrixShape, i, len(row), len(rows[0]))
+		}
+
+		for j, v := range row {
+			m.rows[i][j] = f.Reduce(v)
+		}
+	}
+
+	return m, nil
+}
+
+// IdentityMatrix returns the n×n identity matrix over f.
+func IdentityMatrix(f Field, n int) *Matrix {
+	m := newZeroMatrix(f, n, n)
+	for i := range m.rows {
+		m.rows[i][i] = 1
+	}
+
+	return m
+}
+
+func newZeroMatrix(f Field, rows, cols int) *Matrix {
+	m := &Matrix{f: f, rows: make([][]uint64, rows)}
+	for i := range m.rows {
+		m.rows[i] = make([]uint64, cols)
+	}
+
+	return m
+}
+
+func (m *Matrix) Rows() int {
+	return len(m.rows)
+}
+
+func (m *Matrix) Cols() int {
+	return len(m.rows[0])
+}
+
+// At returns the entry at row i and column j.
+func (m *Matrix) At(i, j int) uint64 {
+	return m.rows[i][j]
+}
+
+// ToSlices returns a copy of the rows of m.
+func (m *Matrix) ToSlices() [][]uint64 {
+	rows := make([][]uint64, len(m.rows))
+	for i, row := range m.rows {
+		rows[i] = append([]uint64(nil), row...)
+	}
+
+	return rows
+}
+
+// Mul returns m * other, where m has as many columns as other has rows.
+func (m *Matrix) Mul(other *Matrix) (*Matrix, error) {
+	if !SameField(m.f, other.f) {
+		return nil, ErrModulusMismatch
+	}
+
+	if m.Cols() != other.Rows() {
+		return nil, fmt.Errorf("%w: %dx%d * %dx%d", ErrMatrixShape, m.Rows(), m.Cols(), other.Rows(), other.Cols())
+	}
+
+	f := m.f
+	prod := newZeroMatrix(f, m.Rows(), other.Cols())
+
+	for i, row := range m.rows {
+		out := prod.rows[i]
+		for l, a := range row {
+			if a == 0 {
+				continue
+			}
+
+			for j, b := range other.rows[l] {
+				out[j] = f.Add(out[j], f.Mul(a, b))
+			}
+		}
+	}
+
+	return prod, nil
+}
+
+// MulVec returns m * v, where len(v) is the number of columns of m.
+func (m *Matrix) MulVec(v []uint64) ([]uint64, error) {
+	if len(v) != m.Cols() {
+		return nil, fmt.Errorf("%w: %dx%d * vector of length %d", ErrMatrixShape, m.Rows(), m.Cols(), len(v))
+	}
+
+	f := m.f
+	out := make([]uint64, m.Rows())
+
+	for i, row := range m.rows {
+		for j, a := range row {
+			out[i] = f.Add(out[i], f.Mul(a, f.Reduce(v[j])))
+		}
+	}
+
+	return out, nil
+}
+
+// Inverse returns the inverse of the square matrix m using Gauss-Jordan elimination,
+// or ErrSingularMatrix if m isn't invertible.
+func (m *Matrix) Inverse() (*Matrix, error) {
+	if m.Rows() != m.Cols() {
+		return nil, fmt.Errorf("%w: only square matrices are invertible, got %dx%d", ErrMatrixShape, m.Rows(), m.Cols())
+	}
+
+	n := m.Rows()
+
+	// augmented [m | I]
+	aug := newZeroMatrix(m.f, n, 2*n)
+	for i, row := range m.rows {
+		copy(aug.rows[i], row)
+		aug.rows[i][n+i] = 1
+	}
+
+	if err := aug.gaussJordan(n); err != nil {
+		return nil, err
+	}
+
+	inverse := &Matrix{f: m.f, rows: make([][]uint64, n)}
+	for i, row := range aug.rows {
+		inverse.rows[i] = row[n:]
+	}
+
+	return inverse, nil
+}
+
+// Solve returns the x with m * x = b, for a square invertible m, or ErrSingularMatrix otherwise.
+// It eliminates [m | b] directly, which is cheaper than multiplying b by the Inverse.
+func (m *Matrix) Solve(b []uint64) ([]uint64, error) {
+	if m.Rows() != m.Cols() || len(b) != m.Rows() {
+		return nil, fmt.Errorf("%w: solving a %dx%d system with %d values", ErrMatrixShape, m.Rows(), m.Cols(), len(b))
+	}
+
+	n := m.Rows()
+
+	aug := newZeroMatrix(m.f, n, n+1)
+	for i, row := range m.rows {
+		copy(aug.rows[i], row)
+		aug.rows[i][n] = m.f.Reduce(b[i])
+	}
+
+	if err := aug.gaussJordan(n); err != nil {
+		return nil, err
+	}
+
+	x := make([]uint64, n)
+	for i, row := range aug.rows {
+		x[i] = row[n]
+	}
+
+	return x, nil
+}
+
+// gaussJordan reduces the n leftmost columns of m, an augmented matrix with n rows, to the identity in place.
+// Any non-zero pivot works over a field, thus the first one in each column is taken.
+func (m *Matrix) gaussJordan(n int) error {
+	f, rows := m.f, m.rows
+
+	for col := 0; col < n; col++ {
+		pivot := -1
+		for r := col; r < n; r++ {
+			if rows[r][col] != 0 {
+				pivot = r
+				break
+			}
+		}
+
+		if pivot < 0 {
+			return ErrSingularMatrix
+		}
+
+		rows[col], rows[pivot] = rows[pivot], rows[col]
+
+		inv := f.Inverse(rows[col][col])
+		for j := range rows[col] {
+			rows[col][j] = f.Mul(rows[col][j], inv)
+		}
+
+		for r := 0; r < n; r++ {
+			if r == col || rows[r][col] == 0 {
+				continue
+			}
+
+			factor := rows[r][col]
+			for j := range rows[r] {
+				rows[r][j] = f.Sub(rows[r][j], f.Mul(factor, rows[col][j]))
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package field
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatrixInverse(t *testing.T) {
+	a := assert.New(t)
+	f, err := NewPrimeField(157)
+	a.NoError(err)
+
+	m, err := NewMatrix(f, [][]uint64{{2, 1, 0}, {0, 0, 3}, {1, 5, 7}})
+	a.NoError(err)
+
+	inv, err := m.Inverse()
+	a.NoError(err)
+
+	prod, err := m.Mul(inv)
+	a.NoError(err)
+	a.Equal(IdentityMatrix(f, 3), prod)
+
+	// the Vandermonde matrix V[i][j] = x_i^j of distinct points is invertible: V^{-1} * y are
+	// the coefficients of the interpolant of (x_i, y_i).
+	xs := []uint64{3, 10, 42, 156}
+	ys := []uint64{1, 2, 3, 4}
+
+	rows := make([][]uint64, len(xs))
+	for i, x := range xs {
+		rows[i] = make([]uint64, len(xs))
+		for j := range rows[i] {
+			rows[i][j] = f.Pow(x, uint64(j))
+		}
+	}
+
+	vandermonde, err := NewMatrix(f, rows)
+	a.NoError(err)
+
+	inv, err = vandermonde.Inverse()
+	a.NoError(err)
+
+	prod, err = inv.Mul(vandermonde)
+	a.NoError(err)
+	a.Equal(IdentityMatrix(f, len(xs)), prod)
+
+	coeffs, err := inv.MulVec(ys)
+	a.NoError(err)
+
+	interpolant, err := NewInterpolator(NewDensePolyRing(f)).Interpolate(xs, ys)
+	a.NoError(err)
+	a.Equal(interpolant.ToSlice(), coeffs)
+
+	// singular and non-square matrices.
+	singular, err := NewMatrix(f, [][]uint64{{1, 2}, {2, 4}})
+	a.NoError(err)
+
+	_, err = singular.Inverse()
+	a.ErrorIs(err, ErrSingularMatrix)
+
+	rect, err := NewMatrix(f, [][]uint64{{1, 2, 3}, {4, 5, 6}})
+	a.NoError(err)
+
+	_, err = rect.Inverse()
+	a.ErrorIs(err, ErrMatrixShape)
+
+	_, err = NewMatrix(f, [][]uint64{{1, 2}, {3}})
+	a.ErrorIs(err, ErrMatrixShape)
+
+	_, err = m.Mul(rect)
+	a.ErrorIs(err, ErrMatrixShape)
+}
+
+func TestMatrixSolve(t *testing.T) {
+	a := assert.New(t)
+	f, err := NewPrimeField(65537)
+	a.NoError(err)
+
+	// 2x + y = 5, x + 3y + z = 15, y + 4z = 23: x = 1, y = 3, z = 5.
+	m, err := NewMatrix(f, [][]uint64{{2, 1, 0}, {1, 3, 1}, {0, 1, 4}})
+	a.NoError(err)
+
+	b := []uint64{5, 15 + 65537, 23} // unreduced values are fine.
+
+	x, err := m.Solve(b)
+	a.NoError(err)
+	a.Equal([]uint64{1, 3, 5}, x)
+
+	mx, err := m.MulVec(x)
+	a.NoError(err)
+	a.Equal([]uint64{5, 15, 23}, mx)
+
+	// a zero pivot needs a row swap.
+	m, err = NewMatrix(f, [][]uint64{{0, 1}, {1, 0}})
+	a.NoError(err)
+
+	x, err = m.Solve([]uint64{7, 9})
+	a.NoError(err)
+	a.Equal([]uint64{9, 7}, x)
+
+	singular, err := NewMatrix(f, [][]uint64{{1, 2}, {2, 4}})
+	a.NoError(err)
+
+	_, err = singular.Solve([]uint64{1, 2})
+	a.ErrorIs(err, ErrSingularMatrix)
+
+	_, err = m.Solve([]uint64{1})
+	a.ErrorIs(err, ErrMatrixShape)
+}
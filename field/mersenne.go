@@ -0,0 +1,110 @@
+package field
+
+import (
+	"errors"
+	"math/bits"
+)
+
+/*
+MersennePrimeField is a prime field of order p = 2^k - 1.
+Since 2^k = 1 (mod p), reduction folds the bits above k onto the low k bits with shifts and adds,
+instead of the division PrimeField uses.
+
+Note p-1 = 2(2^(k-1) - 1), so these fields have no roots of unity of order > 2 and don't support NTT.
+*/
+type MersennePrimeField struct {
+	*PrimeField
+	k uint
+}
+
+var errNotMersennePrime = errors.New("2^k - 1 is not a prime for the given k")
+
+// NewMersennePrimeField returns the field of order 2^k - 1, e.g., k = 31 or 61.
+func NewMersennePrimeField(k uint) (Field, error) {
+	if k < 2 || k > maxBitUsage {
+		return nil, errPrimeTooLarge
+	}
+
+	f, err := NewPrimeField(1<<k - 1)
+	if err != nil {
+		if errors.Is(err, errNotPrime) {
+			return nil, errNotMersennePrime
+		}
+
+		return nil, err
+	}
+
+	return &MersennePrimeField{
+		PrimeField: f.(*PrimeField),
+		k:          k,
+	}, nil
+}
+
+// Clone returns a copy of f, see PrimeField.Clone.
+func (f *MersennePrimeField) Clone() Field {
+	return &MersennePrimeField{PrimeField: f.PrimeField.Clone().(*PrimeField), k: f.k}
+}
+
+func (f *MersennePrimeField) Reduce(val uint64) uint64 {
+	p := f.prime
+	if val < p {
+		return val
+	}
+
+	for val > p {
+		val = (val & p) + (val >> f.k)
+	}
+
+	if val == p {
+		return 0
+	}
+
+	return val
+}
+
+// Mul returns a * b (mod 2^k - 1). Expects reduced inputs.
+func (f *MersennePrimeField) Mul(a, b uint64) uint64 {
+	if a == 0 || b == 0 {
+		return 0
+	}
+
+	return f.mul(a, b)
+}
+
+func (f *MersennePrimeField) mul(a, b uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+
+	// a*b = hi*2^64 + lo = (a*b >> k)*2^k + (lo & p), and 2^k = 1 (mod p).
+	// Since a, b < p, the sum is < 2p.
+	sum := (lo & f.prime) + (hi<<(64-f.k) | lo>>f.k)
+	if sum >= f.prime {
+		sum -= f.prime
+	}
+
+	return sum
+}
+
+func (f *MersennePrimeField) Pow(base, exp uint64) uint64 {
+	base = f.Reduce(base)
+
+	x := uint64(1)
+	for exp > 0 {
+		if exp%2 == 1 {
+			x = f.mul(x, base)
+		}
+
+		base = f.mul(base, base)
+		exp /= 2
+	}
+
+	return f.Reduce(x)
+}
+
+func (f *MersennePrimeField) Inverse(e uint64) uint64 {
+	if e == 0 {
+		panic("zero has no inverse")
+	}
+
+	// Fermat's little theorem, see PrimeField.Inverse.
+	return f.Pow(e, f.prime-2)
+}
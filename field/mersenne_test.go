@@ -0,0 +1,149 @@
+package field
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const mersenne61 = 1<<61 - 1
+
+func TestMersennePrimeField(t *testing.T) {
+	a := assert.New(t)
+
+	_, err := NewMersennePrimeField(11) // 2047 = 23 * 89
+	a.ErrorIs(err, errNotMersennePrime)
+
+	_, err = NewMersennePrimeField(64)
+	a.ErrorIs(err, errPrimeTooLarge)
+
+	for _, k := range []uint{7, 31, 61} {
+		f, err := NewMersennePrimeField(k)
+		a.NoError(err)
+		a.Equal(uint64(1<<k-1), f.Modulus())
+
+		ref, err := NewPrimeField(1<<k - 1)
+		a.NoError(err)
+
+		for _, v := range []uint64{0, 1, 2, f.Modulus() - 1, f.Modulus(), f.Modulus() + 1, 1<<64 - 1} {
+			a.Equal(ref.Reduce(v), f.Reduce(v), "k=%d v=%d", k, v)
+		}
+
+		x := f.Modulus() - 2
+		a.Equal(ref.Mul(x, x), f.Mul(x, x))
+		a.Equal(uint64(1), f.Mul(x, f.Inverse(x)))
+	}
+}
+
+func TestMersennePolyRing(t *testing.T) {
+	a := assert.New(t)
+
+	f, err := NewMersennePrimeField(61)
+	a.NoError(err)
+
+	ref, err := NewPrimeField(mersenne61)
+	a.NoError(err)
+
+	p := randomPolynomial(ref, 1, 100)
+	q := randomPolynomial(ref, 2, 60)
+
+	expected := &Polynomial{}
+	NewDensePolyRing(ref).MulPoly(p, q, expected)
+
+	pm := NewPolynomial(f, p.ToSlice(), false)
+	qm := NewPolynomial(f, q.ToSlice(), false)
+
+	// MersennePrimeField has no large roots of unity, so this multiplication doesn't use NTT.
+	pr := NewDensePolyRing(f, WithMulThreshold(8))
+
+	prod := &Polynomial{}
+	pr.MulPoly(pm, qm, prod)
+	a.Equal(expected.ToSlice(), prod.ToSlice())
+
+	quo, rem := pr.LongDiv(prod, qm)
+	a.True(quo.Equals(pm))
+	a.True(rem.IsZero())
+}
+
+func FuzzMersenneMul(f *testing.F) {
+	f.Add(uint64(0), uint64(0), uint64(0))
+	f.Add(uint64(1), uint64(mersenne61-1), uint64(5))
+	f.Add(uint64(mersenne61-1), uint64(mersenne61-1), uint64(1<<63))
+	f.Add(uint64(1<<64-1), uint64(mersenne61), uint64(mersenne61-2))
+
+	mf, err := NewMersennePrimeField(61)
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	mod := new(big.Int).SetUint64(mersenne61)
+
+	f.Fuzz(func(t *testing.T, a, b, exp uint64) {
+		ra, rb := mf.Reduce(a), mf.Reduce(b)
+
+		want := new(big.Int).Mod(new(big.Int).SetUint64(a), mod).Uint64()
+		if ra != want {
+			t.Fatalf("Reduce(%d) = %d, want %d", a, ra, want)
+		}
+
+		want = new(big.Int).Mod(new(big.Int).Mul(new(big.Int).SetUint64(ra), new(big.Int).SetUint64(rb)), mod).Uint64()
+		if got := mf.Mul(ra, rb); got != want {
+			t.Fatalf("Mul(%d, %d) = %d, want %d", ra, rb, got, want)
+		}
+
+		want = new(big.Int).Exp(new(big.Int).SetUint64(ra), new(big.Int).SetUint64(exp), mod).Uint64()
+		if got := mf.Pow(ra, exp); got != want {
+			t.Fatalf("Pow(%d, %d) = %d, want %d", ra, exp, got, want)
+		}
+	})
+}
+
+/*
+BenchmarkMersenne/PrimeField/Mul         	155148621	         7.783 ns/op
+BenchmarkMersenne/PrimeField/Reduce      	394666537	         3.105 ns/op
+BenchmarkMersenne/MersennePrimeField/Mul 	283852532	         4.111 ns/op
+BenchmarkMersenne/MersennePrimeField/Reduce         	273798405	         3.828 ns/op
+*/
+func BenchmarkMersenne(b *testing.B) {
+	mf, err := NewMersennePrimeField(61)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	pf, err := NewPrimeField(mersenne61)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	e1 := pf.Reduce((1 << 63) - 2)
+	e2 := pf.Reduce((1 << 60) + 312)
+
+	for _, fld := range []struct {
+		name string
+		f    Field
+	}{
+		{"PrimeField", pf},
+		{"MersennePrimeField", mf},
+	} {
+		b.Run(fld.name+"/Mul", func(b *testing.B) {
+			x := e1
+			for i := 0; i < b.N; i++ {
+				x = fld.f.Mul(x, e2)
+			}
+
+			benchElemSink = x
+		})
+
+		b.Run(fld.name+"/Reduce", func(b *testing.B) {
+			x := uint64(0)
+			for i := 0; i < b.N; i++ {
+				x += fld.f.Reduce(uint64(i) * e2)
+			}
+
+			benchElemSink = x
+		})
+	}
+}
+
+var benchElemSink uint64 // avoid DCE
@@ -1,7 +1,19 @@
 // improved from recursive variant(+cache of twiddles) using gpt.
 package field
 
-import "errors"
+import (
+	"errors"
+	"math"
+	"math/bits"
+	"sync"
+	"unsafe"
+)
+
+var (
+	errNttLength      = errors.New("NTT: polynomial length must be a power of two")
+	errNotInNttDomain = errors.New("NTT: polynomial is not in NTT form")
+	errNttDstTooShort = errors.New("NTT: destination is shorter than the source")
+)
 
 type twiddleSet struct {
 	// For each stage s (m = 2<<s), fwd[s] (and inv[s]) has length m/2
@@ -81,6 +93,32 @@ func (pr *DensePolyRing) getTwiddles(n int) (*twiddleSet, error) {
 
 	return ts, nil
 }
+
+// supportsNtt reports whether the field has the roots of unity needed for an NTT of size n.
+func (pr *DensePolyRing) supportsNtt(n int) bool {
+	_, err := pr.getTwiddles(n)
+
+	return err == nil
+}
+
+// PadToPowerOfTwo returns a copy of a, zero-extended to the next power of two number of coefficients,
+// a length NttForward accepts. Panics on NTT inputs, whose length is meaningful.
+func (pr *DensePolyRing) PadToPowerOfTwo(a *Polynomial) *Polynomial {
+	padded := a.Copy()
+	pr.PadToPowerOfTwoInPlace(padded)
+
+	return padded
+}
+
+// PadToPowerOfTwoInPlace is PadToPowerOfTwo that extends a itself.
+func (pr *DensePolyRing) PadToPowerOfTwoInPlace(a *Polynomial) {
+	if a.isNTT {
+		panic("padding not supported in NTT domain")
+	}
+
+	pr.ensureLen(a, nextPow2(len(a.inner)))
+}
+
 func (pr *DensePolyRing) NttForward(a *Polynomial) error {
 	if a == nil || len(a.inner) == 0 {
 		return nil
@@ -88,13 +126,254 @@ func (pr *DensePolyRing) NttForward(a *Polynomial) error {
 	if a.isNTT {
 		return nil
 	}
-	n := len(a.inner)
+
+	if err := pr.NttForwardSlice(a.inner); err != nil {
+		return err
+	}
+
+	a.isNTT = true
+
+	return nil
+}
+
+// NttForwardSlice transforms xs in place, for callers managing their own buffers.
+// len(xs) must be a power of two (an empty xs is a no-op), and xs is left untouched on error.
+func (pr *DensePolyRing) NttForwardSlice(xs []uint64) error {
+	if len(xs) == 0 {
+		return nil
+	}
+
+	if err := pr.reduceNttSlice(xs); err != nil {
+		return err
+	}
+
+	return pr.nttForwardInPlace(xs)
+}
+
+// NttBackwardSlice is the inverse of NttForwardSlice. Unlike NttBackward, it doesn't trim trailing zeros.
+func (pr *DensePolyRing) NttBackwardSlice(xs []uint64) error {
+	if len(xs) == 0 {
+		return nil
+	}
+
+	if err := pr.reduceNttSlice(xs); err != nil {
+		return err
+	}
+
+	return pr.nttBackwardInPlace(xs)
+}
+
+// reduceNttSlice checks that xs can be transformed, then reduces its elements.
+func (pr *DensePolyRing) reduceNttSlice(xs []uint64) error {
+	if !IsPowerOfTwo(uint64(len(xs))) {
+		return errNttLength
+	}
+
+	if _, err := pr.getTwiddles(len(xs)); err != nil {
+		return err
+	}
+
+	for i, v := range xs {
+		xs[i] = pr.Reduce(v)
+	}
+
+	return nil
+}
+
+// NttForwardInto writes the NTT of src into dst, without touching src.
+// src is zero-padded to len(dst), which must be a power of two and at least len(src).
+// Allows callers to reuse dst across transforms.
+func (pr *DensePolyRing) NttForwardInto(dst, src []uint64) error {
+	if len(src) > len(dst) {
+		return errNttDstTooShort
+	}
+
+	if !IsPowerOfTwo(uint64(len(dst))) {
+		return errNttLength
+	}
+
+	for i, v := range src {
+		dst[i] = pr.Reduce(v)
+	}
+	clear(dst[len(src):])
+
+	return pr.nttForwardInPlace(dst)
+}
+
+// The four-step NTT is off by default: the flat transform's stages are linear sweeps, and its cost is
+// dominated by the division in PrimeField.Mul rather than by cache misses. On the machine of
+// BenchmarkNttFourStep the flat transform stayed faster up to n = 2^22.
+// Machines with small caches may benefit from WithFourStepThreshold.
+const defaultFourStepThreshold = math.MaxInt
+
+func (pr *DensePolyRing) nttForwardInPlace(xs []uint64) error {
+	if len(xs) >= pr.fourStepThreshold {
+		return pr.NttForwardFourStep(xs)
+	}
+
+	return pr.nttForwardFlat(xs)
+}
+
+/*
+NttForwardFourStep transforms xs in place like NttForwardSlice (with the same output), but splits the
+length n = n1*n2 transform into n1 transforms of length n2 and n2 transforms of length n1, with a twiddle
+multiplication and transposes in between. Each small transform fits in cache, unlike the strided butterflies
+of the flat transform at large n. len(xs) must be a power of two, and xs must be reduced.
+
+With j = j1 + n1*j2 and k = k2 + n2*k1, psi^(jk) = psi^(n2*j1*k1) * psi^(j1*k2) * psi^(n1*j2*k2), thus:
+X[k2 + n2*k1] = \sum_{j1} psi^(n2*j1*k1) * psi^(j1*k2) * \sum_{j2} psi^(n1*j2*k2) * x[j1 + n1*j2].
+*/
+func (pr *DensePolyRing) NttForwardFourStep(xs []uint64) error {
+	n := len(xs)
+	if !IsPowerOfTwo(uint64(n)) {
+		return errNttLength
+	}
+
+	ts, err := pr.getTwiddles(n)
+	if err != nil {
+		return err
+	}
+
+	if n < 16 {
+		return pr.nttForwardFlat(xs)
+	}
+
+	n1 := 1 << (bits.Len(uint(n-1)) / 2)
+	n2 := n / n1
+
+	// the small transforms use the roots of unity of their own length, which must be powers of psi.
+	psiPows := ts.fwd[len(ts.fwd)-1] // psi^j for j < n/2.
+	half := n / 2
+	sub, err := pr.getTwiddles(n2)
+	if err != nil || sub.fwd[len(sub.fwd)-1][1] != pr.Pow(psiPows[1], uint64(n1)) {
+		return pr.nttForwardFlat(xs)
+	}
+
+	bufPtr := pr.getScratch(n)
+	defer putScratch(bufPtr)
+	buf := *bufPtr
+
+	// buf[j1][j2] = x[j1 + n1*j2], then transform the rows.
+	transpose(buf, xs, n2, n1)
+
+	for j1 := 0; j1 < n1; j1++ {
+		row := buf[j1*n2 : (j1+1)*n2]
+		if err := pr.nttForwardFlat(row); err != nil {
+			return err
+		}
+
+		// multiply by psi^(j1*k2), where j1*k2 < n and psi^(n/2) = -1.
+		for k2, e := 1, j1; k2 < n2; k2, e = k2+1, e+j1 {
+			if e < half {
+				row[k2] = pr.Mul(row[k2], psiPows[e])
+			} else {
+				row[k2] = pr.Neg(pr.Mul(row[k2], psiPows[e-half]))
+			}
+		}
+	}
+
+	// xs[k2][j1], then transform the rows into xs[k2][k1].
+	transpose(xs, buf, n1, n2)
+
+	for k2 := 0; k2 < n2; k2++ {
+		if err := pr.nttForwardFlat(xs[k2*n1 : (k2+1)*n1]); err != nil {
+			return err
+		}
+	}
+
+	// X[k2 + n2*k1] is at xs[k2][k1].
+	transpose(buf, xs, n2, n1)
+	copy(xs, buf)
+
+	return nil
+}
+
+/*
+NttForwardStockham transforms xs in place like NttForwardSlice (with the same, natural, output order), using the
+Stockham auto-sort algorithm: each decimation-in-frequency stage writes its butterflies' outputs interleaved into
+a ping-pong buffer, which sorts the output as it goes instead of bit-reversing the input upfront.
+len(xs) must be a power of two, and xs must be reduced.
+
+It isn't used by NttForward: the bit-reversal is a cheap fraction of the flat transform, whose in-place
+butterflies beat the ping-pong buffer's extra memory traffic, see BenchmarkNttStockham.
+
+At the stage with stride s and sub-transforms of length l = n/s, for p < l/2 and q < s:
+y[q + s*2p] = x[q + s*p] + x[q + s*(p + l/2)], and y[q + s*(2p+1)] = (x[q + s*p] - x[q + s*(p + l/2)]) * psi^(s*p).
+*/
+func (pr *DensePolyRing) NttForwardStockham(xs []uint64) error {
+	n := len(xs)
 	if !IsPowerOfTwo(uint64(n)) {
-		return errors.New("NTTForward: length must be a power of two")
+		return errNttLength
+	}
+
+	ts, err := pr.getTwiddles(n)
+	if err != nil {
+		return err
+	}
+
+	if n < 2 {
+		return nil
+	}
+
+	psiPows := ts.fwd[len(ts.fwd)-1] // psi^j for j < n/2.
+
+	bufPtr := pr.getScratch(n)
+	defer putScratch(bufPtr)
+
+	src, dst := xs, *bufPtr
+	for s, l := 1, n; l >= 2; s, l = s<<1, l>>1 {
+		m := l >> 1
+		for p := 0; p < m; p++ {
+			w := psiPows[s*p]
+
+			x0, x1 := src[s*p:s*p+s], src[s*(p+m):s*(p+m)+s]
+			y0, y1 := dst[2*s*p:2*s*p+s], dst[2*s*p+s:2*s*p+2*s]
+			for q := range x0 {
+				a, b := x0[q], x1[q]
+				y0[q] = pr.Add(a, b)
+				y1[q] = pr.Mul(pr.Sub(a, b), w)
+			}
+		}
+
+		src, dst = dst, src
+	}
+
+	// an odd number of stages leaves the output in the buffer.
+	if &src[0] != &xs[0] {
+		copy(xs, src)
+	}
+
+	return nil
+}
+
+// transposeBlock is the side of the tiles transpose copies, so both the read and written tiles stay in cache.
+const transposeBlock = 32
+
+// transpose writes the transpose of the rows x cols row-major matrix src into dst (cols x rows).
+func transpose(dst, src []uint64, rows, cols int) {
+	for r0 := 0; r0 < rows; r0 += transposeBlock {
+		rEnd := min(r0+transposeBlock, rows)
+
+		for c0 := 0; c0 < cols; c0 += transposeBlock {
+			cEnd := min(c0+transposeBlock, cols)
+
+			for r := r0; r < rEnd; r++ {
+				for c := c0; c < cEnd; c++ {
+					dst[c*rows+r] = src[r*cols+c]
+				}
+			}
+		}
+	}
+}
+
+func (pr *DensePolyRing) nttForwardFlat(xs []uint64) error {
+	n := len(xs)
+	if !IsPowerOfTwo(uint64(n)) {
+		return errNttLength
 	}
 
 	// Bit-reversal permutation (in place; allocation-free)
-	bitReverseInPlace(a.inner)
+	bitReverseInPlace(xs)
 
 	// Twiddles per stage
 	ts, err := pr.getTwiddles(n)
@@ -109,16 +388,14 @@ func (pr *DensePolyRing) NttForward(a *Polynomial) error {
 		for k := 0; k < n; k += m {
 			// breadth-first butterflies
 			for j := 0; j < half; j++ {
-				u := a.inner[k+j]
-				t := pr.Mul(ws[j], a.inner[k+j+half])
-				a.inner[k+j] = pr.Add(u, t)
-				a.inner[k+j+half] = pr.Sub(u, t)
+				u := xs[k+j]
+				t := pr.Mul(ws[j], xs[k+j+half])
+				xs[k+j] = pr.Add(u, t)
+				xs[k+j+half] = pr.Sub(u, t)
 			}
 		}
 	}
 
-	a.isNTT = true
-
 	return nil
 }
 
@@ -136,16 +413,25 @@ func (pr *DensePolyRing) nttBackwardNoTrim(a *Polynomial) error {
 		return nil
 	}
 	if !a.isNTT {
-		return errors.New("newMethod: polynomial is not in NTT form")
+		return errNotInNttDomain
+	}
+
+	if err := pr.NttBackwardSlice(a.inner); err != nil {
+		return err
 	}
 
-	n := len(a.inner)
+	a.isNTT = false
+	return nil
+}
+
+func (pr *DensePolyRing) nttBackwardInPlace(xs []uint64) error {
+	n := len(xs)
 	if !IsPowerOfTwo(uint64(n)) {
-		return errors.New("NTTBackward: length must be a power of two")
+		return errNttLength
 	}
 
 	// Bit-reversal permutation (in place)
-	bitReverseInPlace(a.inner)
+	bitReverseInPlace(xs)
 
 	// Twiddles per stage
 	ts, err := pr.getTwiddles(n)
@@ -159,23 +445,138 @@ func (pr *DensePolyRing) nttBackwardNoTrim(a *Polynomial) error {
 		ws := ts.inv[s]
 		for k := 0; k < n; k += m {
 			for j := 0; j < half; j++ {
-				u := a.inner[k+j]
-				t := pr.Mul(ws[j], a.inner[k+j+half])
-				a.inner[k+j] = pr.Add(u, t)
-				a.inner[k+j+half] = pr.Sub(u, t)
+				u := xs[k+j]
+				t := pr.Mul(ws[j], xs[k+j+half])
+				xs[k+j] = pr.Add(u, t)
+				xs[k+j+half] = pr.Sub(u, t)
 			}
 		}
 	}
 
 	// scale by n^{-1}
 	for i := 0; i < n; i++ {
-		a.inner[i] = pr.Mul(a.inner[i], ts.nInv)
+		xs[i] = pr.Mul(xs[i], ts.nInv)
 	}
 
-	a.isNTT = false
 	return nil
 }
 
+// CyclicConvolve returns c of length n, where c[k] = \sum_{i+j = k (mod n)} a[i]*b[j],
+// i.e., a*b mod (x^n - 1). n must be a power of two dividing p-1;
+// inputs shorter than n are zero-padded. Costs O(n log n).
+func (pr *DensePolyRing) CyclicConvolve(a, b []uint64, n int) ([]uint64, error) {
+	out := pr.makeCoeffs(n)
+	if err := pr.NttForwardInto(out, a); err != nil {
+		return nil, err
+	}
+
+	bBuf := pr.getScratch(n)
+	defer putScratch(bBuf)
+
+	if err := pr.NttForwardInto(*bBuf, b); err != nil {
+		return nil, err
+	}
+
+	for i, v := range *bBuf {
+		out[i] = pr.Mul(out[i], v)
+	}
+
+	if err := pr.nttBackwardInPlace(out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// NegacyclicConvolve returns a*b mod (x^n + 1), namely the cyclic convolution
+// where wrapped-around terms are negated. n must be a power of two and 2n must divide p-1.
+func (pr *DensePolyRing) NegacyclicConvolve(a, b []uint64, n int) ([]uint64, error) {
+	if len(a) > n || len(b) > n {
+		return nil, errNttDstTooShort
+	}
+
+	// psi is a primitive 2n-th root of unity, so psi^n = -1.
+	// Twisting by psi^i turns the negacyclic convolution into a cyclic one.
+	psi, err := pr.GetRootOfUnity(uint64(2 * n))
+	if err != nil {
+		return nil, err
+	}
+
+	twist := func(xs []uint64) []uint64 {
+		out := make([]uint64, n)
+		w := uint64(1)
+		for i, x := range xs {
+			out[i] = pr.Mul(pr.Reduce(x), w)
+			w = pr.Mul(w, psi)
+		}
+
+		return out
+	}
+
+	c, err := pr.CyclicConvolve(twist(a), twist(b), n)
+	if err != nil {
+		return nil, err
+	}
+
+	psiInv := pr.Inverse(psi)
+	w := uint64(1)
+	for i := range c {
+		c[i] = pr.Mul(c[i], w)
+		w = pr.Mul(w, psiInv)
+	}
+
+	return c, nil
+}
+
+// scratchPool recycles the coefficient buffers of NTT-based multiplications.
+var scratchPool = sync.Pool{
+	New: func() any { return new([]uint64) },
+}
+
+// getScratch returns a buffer of length n with arbitrary content, aligned if the ring was built WithAlignedBuffers.
+// The pool is shared by all rings, thus an unaligned pooled buffer is replaced.
+func (pr *DensePolyRing) getScratch(n int) *[]uint64 {
+	buf := scratchPool.Get().(*[]uint64)
+	if cap(*buf) < n || (pr.aligned && !isAligned(*buf)) {
+		*buf = pr.makeCoeffs(n)
+	}
+
+	*buf = (*buf)[:n]
+
+	return buf
+}
+
+// bufferAlignment is the alignment of WithAlignedBuffers, in bytes: a cache line, and an AVX-512 register.
+const bufferAlignment = 64
+
+// makeAligned returns a zeroed slice of n uint64s starting at a bufferAlignment boundary, by over-allocating
+// and slicing from the first aligned element. Its capacity is n, thus appending reallocates without alignment.
+func makeAligned(n int) []uint64 {
+	const perAlignment = bufferAlignment / 8
+
+	buf := make([]uint64, n+perAlignment-1)
+	if n == 0 {
+		return buf[:0:0]
+	}
+
+	// uint64s are 8-byte aligned, so the offset is a whole number of elements.
+	off := 0
+	if rem := uintptr(unsafe.Pointer(&buf[0])) % bufferAlignment; rem != 0 {
+		off = int(bufferAlignment-rem) / 8
+	}
+
+	return buf[off : off+n : off+n]
+}
+
+// isAligned reports whether xs starts at a bufferAlignment boundary. Empty slices are aligned.
+func isAligned(xs []uint64) bool {
+	return len(xs) == 0 || uintptr(unsafe.Pointer(&xs[0]))%bufferAlignment == 0
+}
+
+func putScratch(buf *[]uint64) {
+	scratchPool.Put(buf)
+}
+
 func bitReverseInPlace(xs []uint64) {
 	n := len(xs)
 	if n <= 1 {
@@ -1,6 +1,8 @@
 package field
 
 import (
+	"fmt"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -74,3 +76,424 @@ func TestPolyMult(t *testing.T) {
 		a.True(regMulRes.Equals(nttRes))
 	}
 }
+
+func TestNTTInvalidLength(t *testing.T) {
+	a := assert.New(t)
+	f, err := NewPrimeField(65537)
+	a.NoError(err)
+
+	pr := NewDensePolyRing(f)
+
+	p := NewPolynomial(f, []uint64{1, 2, 3, 4, 5, 6}, true)
+	a.ErrorIs(p.ValidateNttLength(), errNttLength)
+	a.ErrorIs(pr.NttBackward(p), errNttLength)
+
+	p = NewPolynomial(f, []uint64{1, 2, 3, 4, 5, 6}, false)
+	a.ErrorIs(pr.NttForward(p), errNttLength)
+
+	p = NewPolynomial(f, []uint64{1, 2, 3, 4, 5, 6, 7, 8}, false)
+	a.NoError(p.ValidateNttLength())
+	a.ErrorIs(pr.NttBackward(p), errNotInNttDomain)
+}
+
+func TestPadToPowerOfTwo(t *testing.T) {
+	a := assert.New(t)
+	f, err := NewPrimeField(65537)
+	a.NoError(err)
+
+	pr := NewDensePolyRing(f)
+
+	for _, n := range []int{1, 5, 8, 100} {
+		p := randomPolynomial(f, uint64(n), n)
+		if !IsPowerOfTwo(uint64(n)) {
+			a.ErrorIs(pr.NttForward(p.Copy()), errNttLength, "n=%d", n)
+		}
+
+		padded := pr.PadToPowerOfTwo(p)
+		a.Len(padded.ToSlice(), nextPow2(n))
+		a.Len(p.ToSlice(), n) // not modified.
+		a.True(padded.Equals(p))
+
+		for _, x := range []uint64{0, 1, 2, 12345} {
+			a.Equal(pr.Evaluate(p, x), pr.Evaluate(padded, x))
+		}
+
+		a.NoError(pr.NttForward(padded))
+
+		pr.PadToPowerOfTwoInPlace(p)
+		a.Len(p.ToSlice(), nextPow2(n))
+		a.NoError(pr.NttForward(p))
+		a.True(padded.Equals(p))
+	}
+
+	a.Panics(func() { pr.PadToPowerOfTwoInPlace(NewPolynomial(f, []uint64{1, 2, 3}, true)) })
+}
+
+func TestEvaluateAny(t *testing.T) {
+	a := assert.New(t)
+	f, err := NewPrimeField(65537)
+	a.NoError(err)
+
+	pr := NewDensePolyRing(f)
+
+	p := randomPolynomial(f, 12345, 16)
+	pNtt := p.Copy()
+	a.NoError(pr.NttForward(pNtt))
+	nttCpy := pNtt.Copy()
+
+	for _, x := range []uint64{0, 1, 2, 12345, 65536} {
+		a.Equal(pr.Evaluate(p, x), pr.EvaluateAny(pNtt, x))
+		a.Equal(pr.Evaluate(p, x), pr.EvaluateAny(p, x))
+	}
+
+	// not mutated.
+	a.True(pNtt.IsNTT())
+	a.Equal(nttCpy.ToSlice(), pNtt.ToSlice())
+}
+
+func TestNTTForwardInto(t *testing.T) {
+	a := assert.New(t)
+	f, err := NewPrimeField(65537)
+	a.NoError(err)
+
+	pr := NewDensePolyRing(f)
+
+	src := []uint64{1, 2, 3, 4, 5}
+	dst := make([]uint64, 8)
+	for i := range dst {
+		dst[i] = 999 // stale content is overwritten.
+	}
+
+	a.NoError(pr.NttForwardInto(dst, src))
+	a.Equal([]uint64{1, 2, 3, 4, 5}, src)
+
+	p := NewPolynomial(f, []uint64{1, 2, 3, 4, 5, 0, 0, 0}, false)
+	a.NoError(pr.NttForward(p))
+	a.Equal(p.ToSlice(), dst)
+
+	a.ErrorIs(pr.NttForwardInto(make([]uint64, 4), src), errNttDstTooShort)
+	a.ErrorIs(pr.NttForwardInto(make([]uint64, 6), src), errNttLength)
+}
+
+func TestNTTSlice(t *testing.T) {
+	a := assert.New(t)
+	f, err := NewPrimeField(65537)
+	a.NoError(err)
+
+	pr := NewDensePolyRing(f)
+	for i := range 8 {
+		n := 1 << (i + 1)
+
+		p := randomPolynomial(f, 54321+uint64(i), n)
+		xs := p.ToSlice()
+		orig := p.ToSlice()
+
+		a.NoError(pr.NttForward(p))
+		a.NoError(pr.NttForwardSlice(xs))
+		a.Equal(p.ToSlice(), xs)
+
+		a.NoError(pr.NttBackwardSlice(xs))
+		a.Equal(orig, xs)
+	}
+
+	xs := []uint64{1, 2, 3, 4, 5, 0, 0, 0}
+	a.NoError(pr.NttBackwardSlice(xs))
+
+	p := NewPolynomial(f, []uint64{1, 2, 3, 4, 5, 0, 0, 0}, true)
+	a.NoError(pr.NttBackward(p))
+	a.Equal(p.ToSlice(), xs[:len(p.ToSlice())])
+
+	// unreduced inputs.
+	xs = []uint64{65537 + 1, 2}
+	a.NoError(pr.NttForwardSlice(xs))
+	a.Equal([]uint64{3, 65536}, xs)
+
+	bad := []uint64{1, 2, 3, 65537 + 4, 5, 6}
+	a.ErrorIs(pr.NttForwardSlice(bad), errNttLength)
+	a.ErrorIs(pr.NttBackwardSlice(bad), errNttLength)
+	a.Equal([]uint64{1, 2, 3, 65537 + 4, 5, 6}, bad) // untouched.
+
+	a.NoError(pr.NttForwardSlice(nil))
+}
+
+func TestNTTFourStep(t *testing.T) {
+	a := assert.New(t)
+
+	for _, prime := range []uint64{65537, nttFriendlyLargePrime} {
+		f, err := NewPrimeField(prime)
+		a.NoError(err)
+
+		pr := NewDensePolyRing(f).(*DensePolyRing)
+
+		for _, n := range []int{2, 8, 16, 32, 1 << 10, 1 << 11, 1 << 16} {
+			xs := randomPolynomial(f, uint64(n), n).ToSlice()
+			flat := slices.Clone(xs)
+
+			a.NoError(pr.nttForwardFlat(flat))
+			a.NoError(pr.NttForwardFourStep(xs))
+			a.Equal(flat, xs, "prime=%d n=%d", prime, n)
+		}
+	}
+
+	f, err := NewPrimeField(65537)
+	a.NoError(err)
+
+	// NttForward switches to the four-step transform from the threshold on.
+	pr := NewDensePolyRing(f, WithFourStepThreshold(64))
+	ref := NewDensePolyRing(f)
+
+	for _, n := range []int{32, 64, 1 << 12} {
+		p := randomPolynomial(f, 7, n)
+		orig := p.Copy()
+
+		expected := p.Copy()
+		a.NoError(ref.NttForward(expected))
+
+		a.NoError(pr.NttForward(p))
+		a.Equal(expected.ToSlice(), p.ToSlice())
+
+		a.NoError(pr.NttBackward(p))
+		a.True(orig.Equals(p))
+	}
+
+	a.ErrorIs(pr.NttForwardFourStep(make([]uint64, 24)), errNttLength)
+}
+
+/*
+BenchmarkNttFourStep/n=65536/flat         	       5	   8110105 ns/op
+BenchmarkNttFourStep/n=65536/four-step    	       5	  11326056 ns/op
+BenchmarkNttFourStep/n=262144/flat        	       5	  35400049 ns/op
+BenchmarkNttFourStep/n=262144/four-step   	       5	  56603126 ns/op
+BenchmarkNttFourStep/n=1048576/flat       	       5	 166210550 ns/op
+BenchmarkNttFourStep/n=1048576/four-step  	       5	 226229522 ns/op
+*/
+func BenchmarkNttFourStep(b *testing.B) {
+	f, err := NewPrimeField(nttFriendlyLargePrime)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	pr := NewDensePolyRing(f).(*DensePolyRing)
+
+	for _, n := range []int{1 << 16, 1 << 18, 1 << 20} {
+		xs := randomPolynomial(f, 1, n).ToSlice()
+		buf := make([]uint64, n)
+
+		b.Run(fmt.Sprintf("n=%d/flat", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				copy(buf, xs)
+				if err := pr.nttForwardFlat(buf); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("n=%d/four-step", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				copy(buf, xs)
+				if err := pr.NttForwardFourStep(buf); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestNTTStockham(t *testing.T) {
+	a := assert.New(t)
+
+	for _, prime := range []uint64{65537, nttFriendlyLargePrime} {
+		f, err := NewPrimeField(prime)
+		a.NoError(err)
+
+		pr := NewDensePolyRing(f).(*DensePolyRing)
+
+		for _, n := range []int{1, 2, 4, 8, 32, 1 << 9, 1 << 12, 1 << 14} {
+			xs := randomPolynomial(f, uint64(n), n).ToSlice()
+			flat := slices.Clone(xs)
+
+			a.NoError(pr.nttForwardFlat(flat))
+			a.NoError(pr.NttForwardStockham(xs))
+			a.Equal(flat, xs, "prime=%d n=%d", prime, n)
+
+			a.NoError(pr.NttBackwardSlice(xs))
+			a.Equal(randomPolynomial(f, uint64(n), n).ToSlice(), xs)
+		}
+	}
+
+	f, err := NewPrimeField(65537)
+	a.NoError(err)
+
+	pr := NewDensePolyRing(f)
+	a.ErrorIs(pr.NttForwardStockham(make([]uint64, 24)), errNttLength)
+	a.Error(pr.NttForwardStockham(make([]uint64, 1<<17))) // 65537 has no roots of unity of order 2^17.
+}
+
+/*
+BenchmarkNttStockham/n=4096/bit-reversal         	    3279	    374000 ns/op
+BenchmarkNttStockham/n=4096/stockham             	    2125	    520348 ns/op
+BenchmarkNttStockham/n=16384/bit-reversal        	     877	   1442767 ns/op
+BenchmarkNttStockham/n=16384/stockham            	     598	   2628497 ns/op
+*/
+func BenchmarkNttStockham(b *testing.B) {
+	f, err := NewPrimeField(nttFriendlyLargePrime)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	pr := NewDensePolyRing(f).(*DensePolyRing)
+
+	for _, n := range []int{1 << 12, 1 << 14} {
+		xs := randomPolynomial(f, 1, n).ToSlice()
+		buf := make([]uint64, n)
+
+		b.Run(fmt.Sprintf("n=%d/bit-reversal", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				copy(buf, xs)
+				if err := pr.nttForwardFlat(buf); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("n=%d/stockham", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				copy(buf, xs)
+				if err := pr.NttForwardStockham(buf); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestAlignedBuffers(t *testing.T) {
+	a := assert.New(t)
+
+	for _, n := range []int{0, 1, 3, 8, 1000} {
+		xs := makeAligned(n)
+		a.Len(xs, n)
+		a.True(isAligned(xs), "n=%d", n)
+		a.Equal(make([]uint64, n), xs)
+	}
+
+	f, err := NewPrimeField(nttFriendlyLargePrime)
+	a.NoError(err)
+
+	pr := NewDensePolyRing(f)
+	aligned := NewDensePolyRing(f, WithAlignedBuffers(), WithMulThreshold(16), WithFourStepThreshold(1<<10))
+
+	for _, n := range []int{5, 64, 1 << 12} {
+		p := randomPolynomial(f, uint64(n), n)
+
+		// PadToPowerOfTwo reallocates lengths that aren't powers of two, and the four-step transform uses a scratch buffer.
+		padded := aligned.PadToPowerOfTwo(p)
+		if n != 64 {
+			a.True(isAligned(padded.NoCopySlice()))
+		}
+
+		transformed := padded.Copy()
+		a.NoError(aligned.NttForward(transformed))
+
+		expected := pr.PadToPowerOfTwo(p)
+		a.NoError(pr.NttForward(expected))
+		a.Equal(expected.ToSlice(), transformed.ToSlice())
+
+		a.NoError(aligned.NttBackward(transformed))
+		a.True(p.Equals(transformed), "n=%d", n)
+
+		// NTT multiplication allocates its result.
+		q := randomPolynomial(f, uint64(n)+1, n)
+
+		prod, expectedProd := &Polynomial{}, &Polynomial{}
+		aligned.MulPoly(p, q, prod)
+		pr.MulPoly(p, q, expectedProd)
+		a.True(expectedProd.Equals(prod))
+
+		c, err := aligned.CyclicConvolve(p.ToSlice(), q.ToSlice(), nextPow2(n))
+		a.NoError(err)
+		a.True(isAligned(c))
+	}
+}
+
+/*
+A baseline for SIMD butterflies, which the aligned layout prepares for; the scalar loops don't benefit yet.
+BenchmarkAlignedBuffers/n=4096/default         	    3172	    362956 ns/op
+BenchmarkAlignedBuffers/n=4096/aligned         	    3319	    356054 ns/op
+*/
+func BenchmarkAlignedBuffers(b *testing.B) {
+	f, err := NewPrimeField(nttFriendlyLargePrime)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	const n = 1 << 12
+
+	xs := randomPolynomial(f, 1, n).ToSlice()
+
+	for _, tc := range []struct {
+		name string
+		pr   PolyRing
+		buf  []uint64
+	}{
+		{"default", NewDensePolyRing(f), make([]uint64, n)},
+		{"aligned", NewDensePolyRing(f, WithAlignedBuffers()), makeAligned(n)},
+	} {
+		b.Run(fmt.Sprintf("n=%d/%s", n, tc.name), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				copy(tc.buf, xs)
+				if err := tc.pr.NttForwardSlice(tc.buf); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func naiveConvolve(f Field, a, b []uint64, n int, negacyclic bool) []uint64 {
+	out := make([]uint64, n)
+	for i := range a {
+		for j := range b {
+			prod := f.Mul(a[i], b[j])
+			if negacyclic && i+j >= n {
+				prod = f.Neg(prod)
+			}
+
+			out[(i+j)%n] = f.Add(out[(i+j)%n], prod)
+		}
+	}
+
+	return out
+}
+
+func TestConvolutions(t *testing.T) {
+	a := assert.New(t)
+	f, err := NewPrimeField(65537)
+	a.NoError(err)
+
+	pr := NewDensePolyRing(f)
+
+	for _, n := range []int{1, 2, 4, 8, 16, 32} {
+		v1 := randomPolynomial(f, 12345, n).ToSlice()
+		v2 := randomPolynomial(f, 54321+uint64(n), n).ToSlice()
+
+		c, err := pr.CyclicConvolve(v1, v2, n)
+		a.NoError(err)
+		a.Equal(naiveConvolve(f, v1, v2, n, false), c)
+
+		c, err = pr.NegacyclicConvolve(v1, v2, n)
+		a.NoError(err)
+		a.Equal(naiveConvolve(f, v1, v2, n, true), c)
+
+		// shorter inputs are zero-padded.
+		c, err = pr.CyclicConvolve(v1[:n/2], v2, n)
+		a.NoError(err)
+		a.Equal(naiveConvolve(f, v1[:n/2], v2, n, false), c)
+	}
+
+	_, err = pr.CyclicConvolve([]uint64{1, 2, 3}, []uint64{1, 2, 3}, 3)
+	a.ErrorIs(err, errNttLength)
+
+	_, err = pr.NegacyclicConvolve([]uint64{1, 2, 3}, []uint64{1, 2, 3}, 3)
+	a.Error(err)
+}
@@ -1,7 +1,9 @@
 package field
 
 import (
-	"math"
+	"errors"
+	"fmt"
+	"math/big"
 	"strconv"
 	"strings"
 )
@@ -17,6 +19,11 @@ Polynomial expects the coefficients to be in the same field
 and ordered from lowest to highest degree. (e.g. [1, 2, 3] is 1 + 2x + 3x^2)
 
 Can be point representation, generated from numerous evaluation points.
+A point representation may be of any length (e.g., for pointwise products), but
+transforming it with NttBackward requires a power-of-two length; use ValidateNttLength to check it upfront.
+
+inner is stored as is, and the ring operations assume its elements are reduced.
+NewPolynomialReduced reduces them, and NewPolynomialChecked rejects unreduced ones.
 */
 func NewPolynomial(f Field, inner []uint64, isPointRepresentation bool) *Polynomial {
 	// validate inner are all in the same field
@@ -32,20 +39,142 @@ func NewPolynomial(f Field, inner []uint64, isPointRepresentation bool) *Polynom
 	}
 }
 
-func preOpVerification(p, q *Polynomial) bool {
-	if p.f.Modulus() != q.f.Modulus() {
-		return false
+// NewPolynomialReduced is NewPolynomial that reduces every element of inner modulo the field's modulus,
+// in place, thus the polynomial is guaranteed to be in reduced form.
+func NewPolynomialReduced(f Field, inner []uint64, isPointRepresentation bool) *Polynomial {
+	if es, ok := f.(interface{ ElemSlice([]uint64) []uint64 }); ok {
+		es.ElemSlice(inner)
+	} else {
+		for i, c := range inner {
+			inner[i] = f.Reduce(c)
+		}
 	}
 
-	if p.isNTT != q.isNTT {
-		return false
+	return NewPolynomial(f, inner, isPointRepresentation)
+}
+
+var (
+	ErrEmptyPolynomial       = errors.New("polynomial must have at least one coefficient")
+	ErrCoefficientNotReduced = errors.New("coefficient is not a reduced field element")
+)
+
+// NewPolynomialChecked is NewPolynomial for untrusted input: instead of panicking on an empty inner,
+// it returns ErrEmptyPolynomial. Coefficients (or point values) must be reduced field elements,
+// namely f.Reduce(c) == c, otherwise it returns ErrCoefficientNotReduced rather than silently reducing them.
+// inner is used as is, without copying.
+func NewPolynomialChecked(f Field, inner []uint64, isPointRepresentation bool) (*Polynomial, error) {
+	if len(inner) == 0 {
+		return nil, ErrEmptyPolynomial
+	}
+
+	for i, c := range inner {
+		if f.Reduce(c) != c {
+			return nil, fmt.Errorf("%w: inner[%d] = %d", ErrCoefficientNotReduced, i, c)
+		}
+	}
+
+	return NewPolynomial(f, inner, isPointRepresentation), nil
+}
+
+var ErrNegativeCoefficient = errors.New("coefficient must be non-negative")
+
+// SetCoeffsBigInt creates a polynomial in coefficient form from math/big values, e.g., CRT reconstructions,
+// reducing each modulo the field's modulus p; it is meant for prime fields. It returns ErrEmptyPolynomial if coeffs is empty,
+// and ErrNegativeCoefficient if any of them is negative or nil. coeffs is not modified.
+func SetCoeffsBigInt(f Field, coeffs []*big.Int) (*Polynomial, error) {
+	if len(coeffs) == 0 {
+		return nil, ErrEmptyPolynomial
+	}
+
+	q := new(big.Int).SetUint64(f.Modulus())
+	tmp := new(big.Int)
+	inner := make([]uint64, len(coeffs))
+
+	for i, c := range coeffs {
+		if c == nil || c.Sign() < 0 {
+			return nil, fmt.Errorf("%w: coeffs[%d] = %v", ErrNegativeCoefficient, i, c)
+		}
+
+		inner[i] = tmp.Mod(c, q).Uint64()
+	}
+
+	return NewPolynomial(f, inner, false), nil
+}
+
+// CoeffsBigInt returns a copy of p's coefficients (or point values, in NTT form) as math/big values.
+func (p *Polynomial) CoeffsBigInt() []*big.Int {
+	coeffs := make([]*big.Int, len(p.inner))
+	for i, c := range p.inner {
+		coeffs[i] = new(big.Int).SetUint64(c)
+	}
+
+	return coeffs
+}
+
+// ValidateNttLength returns an error if p's length can't be used by the NTT transforms.
+func (p *Polynomial) ValidateNttLength() error {
+	if !IsPowerOfTwo(uint64(len(p.inner))) {
+		return errNttLength
+	}
+
+	return nil
+}
+
+// ToNTT converts p to its point (NTT) representation over pr, zero-padding it to a power of two length first.
+// It is a no-op if p is already in NTT form. On error p remains in coefficient form, possibly padded.
+func (p *Polynomial) ToNTT(pr PolyRing) error {
+	if !SameField(p.f, pr.GetField()) {
+		return ErrModulusMismatch
 	}
 
 	if p.isNTT {
-		return len(p.inner) == len(q.inner)
+		return nil
 	}
 
-	return true
+	pr.PadToPowerOfTwoInPlace(p)
+
+	return pr.NttForward(p)
+}
+
+// FromNTT converts p back to its coefficient representation over pr, trimming the zero padding.
+// It is a no-op if p is already in coefficient form.
+func (p *Polynomial) FromNTT(pr PolyRing) error {
+	if !SameField(p.f, pr.GetField()) {
+		return ErrModulusMismatch
+	}
+
+	if !p.isNTT {
+		return nil
+	}
+
+	return pr.NttBackward(p)
+}
+
+var (
+	ErrModulusMismatch   = errors.New("polynomials are over different fields")
+	ErrDomainMismatch    = errors.New("polynomials are in different domains (coefficient vs NTT)")
+	ErrNttLengthMismatch = errors.New("polynomials in NTT form must have the same length")
+)
+
+// verifyOperands returns why p and q can't be operands of the same ring operation, if at all.
+func verifyOperands(p, q *Polynomial) error {
+	if !SameField(p.f, q.f) {
+		return ErrModulusMismatch
+	}
+
+	if p.isNTT != q.isNTT {
+		return ErrDomainMismatch
+	}
+
+	if p.isNTT && len(p.inner) != len(q.inner) {
+		return ErrNttLengthMismatch
+	}
+
+	return nil
+}
+
+func preOpVerification(p, q *Polynomial) bool {
+	return verifyOperands(p, q) == nil
 }
 
 func (p *Polynomial) IsZero() bool {
@@ -67,19 +196,17 @@ func (p *Polynomial) IsZero() bool {
 	return true
 }
 
-// Polynomial must be trim from leading zeros.
+// Equals compares p and q coefficient-wise. In coefficient form, trailing zeros are treated as absent,
+// so [1, 2] and [1, 2, 0] are equal. Polynomials in NTT form must be of the same length.
 func (p *Polynomial) Equals(q *Polynomial) bool {
 	if !preOpVerification(p, q) {
 		return false
 	}
 
-	if len(p.inner) != len(q.inner) {
-		return false
-	}
-
 	fld := p.f
-	for i := range p.inner {
-		if !fld.Equals(p.inner[i], q.inner[i]) {
+	n := max(len(p.inner), len(q.inner))
+	for i := 0; i < n; i++ {
+		if !fld.Equals(p.coeffAt(i), q.coeffAt(i)) {
 			return false
 		}
 	}
@@ -87,18 +214,70 @@ func (p *Polynomial) Equals(q *Polynomial) bool {
 	return true
 }
 
+// Diff returns the first index at which p and q differ, and their coefficients there, for readable failure messages.
+// Like Equals, trailing zeros are treated as absent, thus missing coefficients read as 0.
+// equal is true (and index is -1) when p equals q. Operands Equals rejects, e.g., over different fields,
+// are reported unequal at index -1.
+func (p *Polynomial) Diff(q *Polynomial) (index int, pv, qv uint64, equal bool) {
+	if !preOpVerification(p, q) {
+		return -1, 0, 0, false
+	}
+
+	n := max(len(p.inner), len(q.inner))
+	for i := 0; i < n; i++ {
+		if pv, qv := p.coeffAt(i), q.coeffAt(i); !p.f.Equals(pv, qv) {
+			return i, pv, qv, false
+		}
+	}
+
+	return -1, 0, 0, true
+}
+
+// MulScalarInPlace sets every coefficient (or point value, in NTT form) of p to its product with s
+// over p's field, and returns p. s may be unreduced: it is reduced once upfront. Unlike the PolyRing's
+// MulScalar, p isn't trimmed.
+func (p *Polynomial) MulScalarInPlace(s uint64) *Polynomial {
+	s = p.f.Reduce(s)
+	for i, c := range p.inner {
+		p.inner[i] = p.f.Mul(c, s)
+	}
+
+	return p
+}
+
+// coeffAt returns the i'th coefficient, or 0 if i is beyond the backing slice.
+func (p *Polynomial) coeffAt(i int) uint64 {
+	if i < len(p.inner) {
+		return p.inner[i]
+	}
+
+	return 0
+}
+
+// DegreeOfZero is the degree of the zero polynomial.
+// Any non-zero polynomial has a larger degree, so comparisons like Degree() < k hold for the zero polynomial as well.
+const DegreeOfZero = -1
+
+// Degree returns the position of the highest non-zero coefficient, or DegreeOfZero for the zero polynomial.
+// Meaningless for polynomials in NTT form.
 func (p *Polynomial) Degree() int {
 	return p.leadingCoeffPos()
 }
 
+// LeadCoeff returns the highest non-zero coefficient, or 0 for the zero polynomial.
 func (p *Polynomial) LeadCoeff() uint64 {
-	if pos := p.leadingCoeffPos(); pos >= 0 {
+	if pos := p.leadingCoeffPos(); pos != DegreeOfZero {
 		return p.inner[pos]
 	}
 
 	return 0
 }
 
+// IsMonic reports whether p's leading coefficient is 1. The zero polynomial is not monic.
+func (p *Polynomial) IsMonic() bool {
+	return p.leadingCoeffPos() != DegreeOfZero && p.f.Reduce(p.LeadCoeff()) == 1
+}
+
 func (p *Polynomial) leadingCoeffPos() int {
 	for i := len(p.inner) - 1; i >= 0; i-- {
 		if p.inner[i] != 0 {
@@ -106,7 +285,20 @@ func (p *Polynomial) leadingCoeffPos() int {
 		}
 	}
 
-	return math.MinInt
+	return DegreeOfZero
+}
+
+// Trim removes the trailing zero coefficients of p in place, leaving the zero polynomial as [0], and returns p.
+// Polynomials in NTT form are left untouched, since their length is meaningful.
+//
+// Canonical form: the PolyRing operations (AddPoly, SubPoly, MulPoly, LongDiv, NttBackward, etc.) return
+// trimmed polynomials, though their zero polynomial may be empty rather than [0].
+// NewPolynomial and Copy keep the length they are given, so call Trim before comparing ToSlice outputs.
+// Equals ignores trailing zeros either way.
+func (p *Polynomial) Trim() *Polynomial {
+	p.removeLeadingZeroes()
+
+	return p
 }
 
 func (p *Polynomial) removeLeadingZeroes() {
@@ -115,7 +307,7 @@ func (p *Polynomial) removeLeadingZeroes() {
 	}
 
 	lead := p.leadingCoeffPos()
-	if lead < 0 {
+	if lead == DegreeOfZero {
 		p.inner = []uint64{0}
 
 		return
@@ -124,6 +316,30 @@ func (p *Polynomial) removeLeadingZeroes() {
 	p.inner = p.inner[:lead+1]
 }
 
+// EnsureCapacity grows the backing slice of p so it can hold at least n coefficients
+// without reallocating. The coefficients and length of p are left unchanged.
+//
+// A pre-sized polynomial can be passed as the output `c` of the PolyRing operations
+// (e.g., MulPoly, AddPoly) to avoid allocations. Aliasing rules:
+//   - c may alias a or b in AddPoly, SubPoly, MulScalar and MulPoly.
+//   - MulPoly writes its result into c's backing slice only if c is distinct from a and b,
+//     otherwise it allocates.
+//   - c must not share a backing slice with a or b without being the same *Polynomial.
+func (p *Polynomial) EnsureCapacity(n int) {
+	if cap(p.inner) >= n {
+		return
+	}
+
+	tmp := make([]uint64, len(p.inner), n)
+	copy(tmp, p.inner)
+	p.inner = tmp
+}
+
+// DegreeBound returns the number of coefficients p can hold without reallocating.
+func (p *Polynomial) DegreeBound() int {
+	return cap(p.inner)
+}
+
 func (p *Polynomial) Copy() *Polynomial {
 	innercopy := make([]uint64, len(p.inner))
 	copy(innercopy, p.inner)
@@ -174,6 +390,14 @@ func (p *Polynomial) NoCopySlice() []uint64 {
 	return p.inner
 }
 
+// IsCoeffMode reports whether p is in NTT form, despite its name.
+//
+// Deprecated: use IsNTT, which reads as what it reports.
 func (p *Polynomial) IsCoeffMode() bool {
 	return p.isNTT
 }
+
+// IsNTT reports whether p is in NTT form, namely, holds evaluations rather than coefficients.
+func (p *Polynomial) IsNTT() bool {
+	return p.isNTT
+}
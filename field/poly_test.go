@@ -1,7 +1,12 @@
 package field
 
 import (
+	"context"
 	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"slices"
 	"testing"
 	"time"
 
@@ -353,6 +358,84 @@ func FuzzPEEA(f *testing.F) {
 	})
 }
 
+func TestPartialExtendedEuclideanTraced(t *testing.T) {
+	a := assert.New(t)
+
+	f, err := NewPrimeField(65537)
+	a.NoError(err)
+
+	pr := NewDensePolyRing(f)
+
+	// x^3 = x*(x^2+1) - x, x^2+1 = (-x)(-x) + 1, -x = (-x)*1.
+	p1 := NewPolynomial(f, []uint64{0, 0, 0, 1}, false)
+	p2 := NewPolynomial(f, []uint64{1, 0, 1}, false)
+
+	type step struct{ iter, remDeg, xDeg, yDeg int } // zero polynomials have degree DegreeOfZero.
+
+	var steps []step
+	gcd, x, y := pr.PartialExtendedEuclideanTraced(p1, p2, 0, func(iter, remDeg, xDeg, yDeg int) {
+		steps = append(steps, step{iter, remDeg, xDeg, yDeg})
+	})
+
+	a.Equal([]step{{0, 2, DegreeOfZero, 0}, {1, 1, 0, 1}, {2, 0, 1, 2}}, steps)
+	a.Equal(0, gcd.Degree())
+	a.Equal(1, x.Degree())
+	a.Equal(2, y.Degree())
+
+	// stops once the remainder's degree is below the stop degree.
+	steps = steps[:0]
+	pr.PartialExtendedEuclideanTraced(p1, p2, 2, func(iter, remDeg, xDeg, yDeg int) {
+		steps = append(steps, step{iter, remDeg, xDeg, yDeg})
+	})
+	a.Equal([]step{{0, 2, DegreeOfZero, 0}, {1, 1, 0, 1}}, steps)
+
+	// nil callback, same as PartialExtendedEuclidean.
+	p3 := randomPolynomial(f, 3, 40)
+	p4 := randomPolynomial(f, 17, 31)
+
+	g1, x1, y1 := pr.PartialExtendedEuclidean(p3, p4, 20)
+	g2, x2, y2 := pr.PartialExtendedEuclideanTraced(p3, p4, 20, nil)
+	a.True(g1.Equals(g2))
+	a.True(x1.Equals(x2))
+	a.True(y1.Equals(y2))
+}
+
+func TestPartialExtendedEuclideanContext(t *testing.T) {
+	a := assert.New(t)
+
+	f, err := NewPrimeField(65537)
+	a.NoError(err)
+
+	pr := NewDensePolyRing(f)
+
+	p1 := randomPolynomial(f, 3, 40)
+	p2 := randomPolynomial(f, 17, 31)
+
+	gcd, x, y := pr.PartialExtendedEuclidean(p1, p2, 20)
+	nttGcd, nttX, nttY := pr.NttPartialExtendedEuclidean(p1, p2, 20)
+
+	g1, x1, y1, err := pr.PartialExtendedEuclideanContext(context.Background(), p1, p2, 20)
+	a.NoError(err)
+	a.True(gcd.Equals(g1) && x.Equals(x1) && y.Equals(y1))
+
+	g2, x2, y2, err := pr.NttPartialExtendedEuclideanContext(context.Background(), p1, p2, 20)
+	a.NoError(err)
+	a.True(nttGcd.Equals(g2) && nttX.Equals(x2) && nttY.Equals(y2))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, _, err = pr.PartialExtendedEuclideanContext(ctx, p1, p2, 20)
+	a.ErrorIs(err, context.Canceled)
+
+	_, _, _, err = pr.NttPartialExtendedEuclideanContext(ctx, p1, p2, 20)
+	a.ErrorIs(err, context.Canceled)
+
+	// no steps, no checks.
+	_, _, _, err = pr.PartialExtendedEuclideanContext(ctx, p1, p2, 100)
+	a.NoError(err)
+}
+
 func randomPolynomial(f Field, seed uint64, maxDegree int) *Polynomial {
 	coefficients := make([]uint64, maxDegree)
 	for i := 0; i < maxDegree; i++ {
@@ -378,6 +461,46 @@ func BenchmarkPolyDiv(b *testing.B) {
 	}
 }
 
+/*
+AddPoly over a PrimeField, before and after its devirtualized loop with ReduceBarrett:
+BenchmarkAddPolyUnreduced/reduced     	   25437	     50779 ns/op
+BenchmarkAddPolyUnreduced/unreduced   	   19027	     59948 ns/op
+
+BenchmarkAddPolyUnreduced/reduced     	  220432	     12193 ns/op
+BenchmarkAddPolyUnreduced/unreduced   	  152318	     29156 ns/op
+*/
+func BenchmarkAddPolyUnreduced(b *testing.B) {
+	f, err := NewPrimeField(largePrime)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	pr := NewDensePolyRing(f)
+
+	const n = 4096
+
+	for _, tc := range []struct {
+		name string
+		or   uint64
+	}{{"reduced", 0}, {"unreduced", 1 << 63}} {
+		xs, ys := make([]uint64, n), make([]uint64, n)
+		for i := range xs {
+			xs[i] = f.Reduce(uint64(i)*0x9e3779b97f4a7c15) | tc.or
+			ys[i] = f.Reduce(uint64(i)*0xbf58476d1ce4e5b9) | tc.or
+		}
+
+		p1, p2 := NewPolynomial(f, xs, false), NewPolynomial(f, ys, false)
+		c := &Polynomial{}
+		c.EnsureCapacity(n)
+
+		b.Run(tc.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				pr.AddPoly(p1, p2, c)
+			}
+		})
+	}
+}
+
 // TODO: Optimise object creation. We spend a lot of time creating new objects.
 func BenchmarkPEEA(b *testing.B) {
 	f, err := NewPrimeField(largePrime)
@@ -417,6 +540,45 @@ func BenchmarkPEEA(b *testing.B) {
 	}
 }
 
+/*
+Gao-shaped inputs: quotients of degree 1 dominate, thus schoolbook division wins although the inputs are large.
+Picking the divider by the quotient's length (see BenchmarkDivThreshold), rather than by len(A)+len(B):
+
+	p=65537/n=1024: 87189828 -> 17687244 ns/op
+	p=65537/n=4096: 2220583426 -> 710571669 ns/op
+
+With p=largePrime, which has no large NTTs, NttPartialExtendedEuclidean used to panic on these sizes.
+*/
+func BenchmarkNttPEEA(b *testing.B) {
+	for _, prime := range []uint64{65537, largePrime} {
+		f, err := NewPrimeField(prime)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		pr := NewDensePolyRing(f)
+		rnd := rand.New(rand.NewSource(1))
+
+		for _, n := range []int{1024, 4096} {
+			g0 := make([]uint64, n+1)
+			g0[0], g0[n] = f.Neg(1), 1
+
+			g1 := make([]uint64, n)
+			for i := range g1 {
+				g1[i] = rnd.Uint64() % prime
+			}
+
+			p0, p1 := NewPolynomial(f, g0, false), NewPolynomial(f, g1, false)
+
+			b.Run(fmt.Sprintf("p=%d/n=%d", prime, n), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					pr.NttPartialExtendedEuclidean(p0, p1, 3*n/4)
+				}
+			})
+		}
+	}
+}
+
 func makeRoots(n int) []uint64 {
 	roots := make([]uint64, n)
 	for i := 0; i < n; i++ {
@@ -445,6 +607,56 @@ func TestLocatorPolynomial(t *testing.T) {
 	}
 }
 
+func TestPolyProductTree(t *testing.T) {
+	a := assert.New(t)
+
+	for _, prime := range []uint64{65537, largePrime} {
+		f, err := NewPrimeField(prime)
+		a.NoError(err)
+
+		// a small threshold to exercise the NTT path where possible.
+		pr := NewDensePolyRing(f, WithMulThreshold(8))
+		intr := NewInterpolator(pr)
+
+		for _, n := range []int{0, 1, 2, 3, 15, 64, 257} {
+			miSlice := intr.createMiSlice(makeRoots(n))
+
+			expected := PolyProduct(pr, miSlice)
+			a.True(expected.Equals(PolyProductTree(pr, miSlice)), "prime=%d n=%d", prime, n)
+			a.True(expected.Equals(PolyProductMonicNegRoots(f, makeRoots(n))), "prime=%d n=%d", prime, n)
+		}
+	}
+}
+
+func TestVanishingPolynomial(t *testing.T) {
+	a := assert.New(t)
+
+	for _, prime := range []uint64{65537, largePrime} {
+		f, err := NewPrimeField(prime)
+		a.NoError(err)
+
+		pr := NewDensePolyRing(f, WithMulThreshold(8))
+
+		for _, n := range []int{0, 1, 2, 17, 300} {
+			roots := makeRoots(n)
+
+			expected := PolyProductMonicNegRoots(f, roots)
+			a.True(expected.Equals(VanishingPolynomial(pr, roots)), "prime=%d n=%d", prime, n)
+
+			// cached, and independent of the order of the points.
+			reversed := slices.Clone(roots)
+			slices.Reverse(reversed)
+
+			cached := VanishingPolynomial(pr, reversed)
+			a.True(expected.Equals(cached), "prime=%d n=%d", prime, n)
+
+			// mutating the returned copy doesn't affect the cache.
+			pr.AddPoly(cached, cached, cached)
+			a.True(expected.Equals(VanishingPolynomial(pr, roots)), "prime=%d n=%d", prime, n)
+		}
+	}
+}
+
 var benchPolySink *Polynomial // avoid DCE
 
 /*
@@ -483,6 +695,84 @@ func BenchmarkPolyProductMonicNegRoots(b *testing.B) {
 	}
 }
 
+/*
+BenchmarkPolyProductTree/n=256/PolyProduct         	       3	    967616 ns/op	  280610 B/op	     258 allocs/op
+BenchmarkPolyProductTree/n=256/PolyProductTree     	       3	    417755 ns/op	   37088 B/op	     521 allocs/op
+BenchmarkPolyProductTree/n=1024/PolyProduct        	       3	  12626618 ns/op	 4480040 B/op	    1026 allocs/op
+BenchmarkPolyProductTree/n=1024/PolyProductTree    	       3	   2875762 ns/op	  187392 B/op	    2071 allocs/op
+BenchmarkPolyProductTree/n=4096/PolyProduct        	       3	 213913428 ns/op	70733608 B/op	    4098 allocs/op
+BenchmarkPolyProductTree/n=4096/PolyProductTree    	       3	  17300781 ns/op	  905192 B/op	    8266 allocs/op
+*/
+func BenchmarkPolyProductTree(b *testing.B) {
+	f, err := NewPrimeField(65537)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	pr := NewDensePolyRing(f)
+	intr := NewInterpolator(pr)
+
+	for _, n := range []int{256, 1024, 4096} {
+		miSlice := intr.createMiSlice(makeRoots(n))
+
+		b.Run(fmt.Sprintf("n=%d/PolyProduct", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				benchPolySink = PolyProduct(pr, miSlice)
+			}
+		})
+
+		b.Run(fmt.Sprintf("n=%d/PolyProductTree", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				benchPolySink = PolyProductTree(pr, miSlice)
+			}
+		})
+	}
+}
+
+/*
+BenchmarkVanishingPolynomial/n=4096/PolyProductMonicNegRoots         	      32	  54651344 ns/op	   41008 B/op	       2 allocs/op
+BenchmarkVanishingPolynomial/n=4096/VanishingPolynomial/cold         	      87	  15833293 ns/op	 1306622 B/op	   16463 allocs/op
+BenchmarkVanishingPolynomial/n=4096/VanishingPolynomial/cached       	   11860	    105961 ns/op	  106544 B/op	       4 allocs/op
+*/
+func BenchmarkVanishingPolynomial(b *testing.B) {
+	f, err := NewPrimeField(65537)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	pr := NewDensePolyRing(f)
+
+	n := 4096
+	roots := makeRoots(n)
+
+	b.Run(fmt.Sprintf("n=%d/PolyProductMonicNegRoots", n), func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			benchPolySink = PolyProductMonicNegRoots(f, roots)
+		}
+	})
+
+	b.Run(fmt.Sprintf("n=%d/VanishingPolynomial/cold", n), func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			vanishingCache.Lock()
+			clear(vanishingCache.entries)
+			vanishingCache.Unlock()
+
+			benchPolySink = VanishingPolynomial(pr, roots)
+		}
+	})
+
+	b.Run(fmt.Sprintf("n=%d/VanishingPolynomial/cached", n), func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			benchPolySink = VanishingPolynomial(pr, roots)
+		}
+	})
+}
+
 func TestDivNTT(t *testing.T) {
 	a := assert.New(t)
 	f, err := NewPrimeField(65537)
@@ -605,3 +895,919 @@ func FuzzNttPEEA(f *testing.F) {
 		}
 	})
 }
+
+func FuzzMulPolyParallel(f *testing.F) {
+	testcases := []uint64{1, 5, 1 << 62, (1 << 63) - 1, 321, 5546}
+	for _, tc := range testcases {
+		f.Add(tc) // Use f.Add to provide a seed corpus
+	}
+
+	fld, err := NewPrimeField(largePrime)
+	if err != nil {
+		f.FailNow()
+	}
+
+	pr := NewDensePolyRing(fld)
+
+	f.Fuzz(func(t *testing.T, randomSeed uint64) {
+		degA := int(randomSeed%300) + 1
+		degB := int((randomSeed/300)%300) + 1
+
+		a := randomPolynomial(fld, randomSeed, degA)
+		b := randomPolynomial(fld, randomSeed/7, degB)
+
+		expected := &Polynomial{}
+		pr.MulPoly(a, b, expected)
+
+		for _, workers := range []int{0, 1, 3, 8} {
+			got := &Polynomial{}
+			pr.MulPolyParallel(a, b, got, workers)
+
+			if !expected.Equals(got) {
+				t.Fatalf("workers=%d: expected %v, got %v", workers, expected, got)
+			}
+		}
+
+		// in place.
+		aCpy := a.Copy()
+		pr.MulPolyParallel(aCpy, b, aCpy, 4)
+		if !expected.Equals(aCpy) {
+			t.Fatalf("in place: expected %v, got %v", expected, aCpy)
+		}
+	})
+}
+
+func BenchmarkMulPolyParallel(b *testing.B) {
+	f, err := NewPrimeField(largePrime)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	pr := NewDensePolyRing(f)
+
+	p1 := randomPolynomial(f, largePrime/4, 200)
+	p2 := randomPolynomial(f, largePrime/7, 200)
+
+	b.Run("MulPoly", func(b *testing.B) {
+		c := &Polynomial{}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			pr.MulPoly(p1, p2, c)
+		}
+	})
+
+	for _, workers := range []int{2, 4, 8} {
+		b.Run(fmt.Sprintf("MulPolyParallel/workers=%d", workers), func(b *testing.B) {
+			c := &Polynomial{}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				pr.MulPolyParallel(p1, p2, c, workers)
+			}
+		})
+	}
+}
+
+func TestEnsureCapacity(t *testing.T) {
+	a := assert.New(t)
+
+	f, err := NewPrimeField(157)
+	a.NoError(err)
+
+	p := NewPolynomial(f, []uint64{1, 2, 3}, false)
+	a.Equal(3, p.DegreeBound())
+
+	p.EnsureCapacity(10)
+	a.Equal(10, p.DegreeBound())
+	a.Equal([]uint64{1, 2, 3}, p.ToSlice())
+
+	// never shrinks.
+	p.EnsureCapacity(2)
+	a.Equal(10, p.DegreeBound())
+
+	pr := NewDensePolyRing(f)
+
+	// in place multiplication with spare capacity must not clobber the input.
+	pr.MulPoly(p, p, p)
+	a.Equal([]uint64{1, 4, 10, 12, 9}, p.ToSlice())
+}
+
+func BenchmarkPreSizedDestination(b *testing.B) {
+	f, err := NewPrimeField(largePrime)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	pr := NewDensePolyRing(f)
+
+	p1 := randomPolynomial(f, largePrime/4, 128)
+	p2 := randomPolynomial(f, largePrime/7, 128)
+
+	b.Run("MulPoly", func(b *testing.B) {
+		c := &Polynomial{f: f}
+		c.EnsureCapacity(len(p1.inner) + len(p2.inner) - 1)
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			pr.MulPoly(p1, p2, c)
+		}
+	})
+
+	b.Run("AddPoly", func(b *testing.B) {
+		c := &Polynomial{f: f}
+		c.EnsureCapacity(len(p1.inner))
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			pr.AddPoly(p1, p2, c)
+		}
+	})
+}
+
+func TestPolyEqualsTrailingZeros(t *testing.T) {
+	a := assert.New(t)
+
+	f, err := NewPrimeField(157)
+	a.NoError(err)
+
+	p := NewPolynomial(f, []uint64{1, 2}, false)
+
+	a.True(p.Equals(NewPolynomial(f, []uint64{1, 2, 0}, false)))
+	a.True(NewPolynomial(f, []uint64{1, 2, 0, 0, 0}, false).Equals(p))
+	a.True(NewPolynomial(f, []uint64{0}, false).Equals(NewPolynomial(f, []uint64{0, 0, 0}, false)))
+	a.True(p.Equals(NewPolynomial(f, []uint64{158, 2, 157}, false))) // unreduced.
+
+	a.False(p.Equals(NewPolynomial(f, []uint64{1, 2, 3}, false)))
+	a.False(p.Equals(NewPolynomial(f, []uint64{1, 0, 2}, false)))
+	a.False(p.Equals(NewPolynomial(f, []uint64{1}, false)))
+
+	// NTT form keeps a fixed size.
+	a.False(NewPolynomial(f, []uint64{1, 2}, true).Equals(NewPolynomial(f, []uint64{1, 2, 0, 0}, true)))
+	a.False(p.Equals(NewPolynomial(f, []uint64{1, 2}, true)))
+}
+
+func TestTryPolyOps(t *testing.T) {
+	a := assert.New(t)
+
+	f, err := NewPrimeField(65537)
+	a.NoError(err)
+
+	other, err := NewPrimeField(157)
+	a.NoError(err)
+
+	pr := NewDensePolyRing(f)
+
+	p := NewPolynomial(f, []uint64{1, 2, 3}, false)
+	cases := []struct {
+		name string
+		a, b *Polynomial
+		err  error
+	}{
+		{"modulus", p, NewPolynomial(other, []uint64{1, 2}, false), ErrModulusMismatch},
+		{"domain", p, NewPolynomial(f, []uint64{1, 2, 3, 4}, true), ErrDomainMismatch},
+		{"ntt length", NewPolynomial(f, []uint64{1, 2}, true), NewPolynomial(f, []uint64{1, 2, 3, 4}, true), ErrNttLengthMismatch},
+	}
+
+	ops := map[string]func(a, b, c *Polynomial) error{
+		"add": pr.TryAddPoly,
+		"sub": pr.TrySubPoly,
+		"mul": pr.TryMulPoly,
+	}
+
+	for opName, op := range ops {
+		for _, tc := range cases {
+			c := NewPolynomial(f, []uint64{7}, false)
+			a.ErrorIs(op(tc.a, tc.b, c), tc.err, "%s: %s", opName, tc.name)
+			a.Equal([]uint64{7}, c.ToSlice(), "%s: %s", opName, tc.name) // untouched.
+		}
+
+		c := &Polynomial{}
+		a.NoError(op(p, NewPolynomial(f, []uint64{4, 5}, false), c), opName)
+	}
+
+	// the panicking variants report the same errors.
+	a.PanicsWithError(ErrDomainMismatch.Error(), func() { pr.AddPoly(cases[1].a, cases[1].b, &Polynomial{}) })
+	a.PanicsWithError(ErrModulusMismatch.Error(), func() { pr.MulPoly(cases[0].a, cases[0].b, &Polynomial{}) })
+}
+
+func TestPolyTrim(t *testing.T) {
+	a := assert.New(t)
+
+	f, err := NewPrimeField(157)
+	a.NoError(err)
+
+	a.Equal([]uint64{1, 2}, NewPolynomial(f, []uint64{1, 2, 0, 0}, false).Trim().ToSlice())
+	a.Equal([]uint64{1, 2}, NewPolynomial(f, []uint64{1, 2}, false).Trim().ToSlice())
+	a.Equal([]uint64{0}, NewPolynomial(f, []uint64{0, 0, 0}, false).Trim().ToSlice())
+
+	// in place.
+	p := NewPolynomial(f, []uint64{0, 3, 0}, false)
+	p.Trim()
+	a.Equal([]uint64{0, 3}, p.ToSlice())
+
+	// NTT form keeps its length.
+	a.Equal([]uint64{1, 2, 0, 0}, NewPolynomial(f, []uint64{1, 2, 0, 0}, true).Trim().ToSlice())
+
+	// equal polynomials have equal trimmed slices.
+	pr := NewDensePolyRing(f)
+	sum := &Polynomial{}
+	pr.AddPoly(NewPolynomial(f, []uint64{1, 2, 5}, false), NewPolynomial(f, []uint64{0, 0, 152}, false), sum)
+	a.Equal(NewPolynomial(f, []uint64{1, 2, 0, 0}, false).Trim().ToSlice(), sum.Trim().ToSlice())
+}
+
+func TestMonic(t *testing.T) {
+	a := assert.New(t)
+
+	f, err := NewPrimeField(157)
+	a.NoError(err)
+
+	pr := NewDensePolyRing(f)
+
+	p := NewPolynomial(f, []uint64{1, 2, 3, 5, 0}, false)
+
+	monic, lead := pr.Monic(p)
+	a.Equal(uint64(5), lead)
+	a.Equal(uint64(1), monic.LeadCoeff())
+	a.Equal([]uint64{1, 2, 3, 5, 0}, p.ToSlice()) // not mutated.
+
+	back := &Polynomial{}
+	pr.MulScalar(monic, lead, back)
+	a.True(p.Equals(back))
+
+	// already monic.
+	monic2, lead := pr.Monic(monic)
+	a.Equal(uint64(1), lead)
+	a.True(monic.Equals(monic2))
+	a.NotSame(monic, monic2)
+
+	a.Panics(func() { pr.Monic(NewPolynomial(f, []uint64{0, 0}, false)) })
+	a.Panics(func() { pr.Monic(NewPolynomial(f, []uint64{1, 2}, true)) })
+}
+
+func TestDiff(t *testing.T) {
+	a := assert.New(t)
+
+	f, err := NewPrimeField(157)
+	a.NoError(err)
+
+	p := NewPolynomial(f, []uint64{1, 2, 3, 4}, false)
+
+	index, pv, qv, equal := p.Diff(NewPolynomial(f, []uint64{1, 2, 5, 4}, false))
+	a.False(equal)
+	a.Equal(2, index)
+	a.Equal(uint64(3), pv)
+	a.Equal(uint64(5), qv)
+
+	// trailing zeros are ignored, and a missing coefficient is 0.
+	index, _, _, equal = p.Diff(NewPolynomial(f, []uint64{1, 2, 3, 4, 0, 0}, false))
+	a.True(equal)
+	a.Equal(-1, index)
+
+	index, pv, qv, equal = p.Diff(NewPolynomial(f, []uint64{1, 2, 3, 4, 0, 7}, false))
+	a.False(equal)
+	a.Equal(5, index)
+	a.Equal(uint64(0), pv)
+	a.Equal(uint64(7), qv)
+
+	// agrees with Equals, also on incomparable operands.
+	for _, q := range []*Polynomial{p.Copy(), NewPolynomial(f, []uint64{1, 2, 3, 4}, true)} {
+		_, _, _, equal = p.Diff(q)
+		a.Equal(p.Equals(q), equal)
+	}
+}
+
+func TestMulScalarInPlace(t *testing.T) {
+	a := assert.New(t)
+
+	for _, prime := range []uint64{157, largePrime} {
+		f, err := NewPrimeField(prime)
+		a.NoError(err)
+
+		pr := NewDensePolyRing(f)
+		p := randomPolynomial(f, 12345, 16)
+
+		for _, s := range []uint64{0, 1, 5, prime - 1, prime, prime + 5, 3*prime + 2, math.MaxUint64} {
+			expected := &Polynomial{}
+			pr.MulScalar(p, s, expected)
+
+			// unreduced scalars are reduced first.
+			a.True(expected.Equals(p.Copy().MulScalarInPlace(s)), "p=%d s=%d", prime, s)
+			a.True(expected.Equals(p.Copy().MulScalarInPlace(f.Reduce(s))))
+
+			inPlace := p.Copy()
+			pr.MulScalarInPlace(inPlace, s)
+			a.Equal(expected.ToSlice(), inPlace.ToSlice())
+		}
+
+		// in NTT form, the length is kept.
+		pNtt := NewPolynomial(f, p.ToSlice(), true)
+
+		pNtt.MulScalarInPlace(prime)
+		a.Len(pNtt.ToSlice(), 16)
+		a.True(pNtt.IsZero())
+	}
+}
+
+func TestToNTT(t *testing.T) {
+	a := assert.New(t)
+
+	f, err := NewPrimeField(65537)
+	a.NoError(err)
+
+	pr := NewDensePolyRing(f)
+
+	for _, size := range []int{1, 13, 16, 100} {
+		p := randomPolynomial(f, uint64(size), size)
+		orig := p.Copy()
+
+		a.NoError(p.ToNTT(pr))
+		a.True(p.IsNTT())
+		a.NoError(p.ValidateNttLength())
+
+		expected := pr.PadToPowerOfTwo(orig)
+		a.NoError(pr.NttForward(expected))
+		a.Equal(expected.ToSlice(), p.ToSlice())
+
+		// idempotent.
+		a.NoError(p.ToNTT(pr))
+		a.Equal(expected.ToSlice(), p.ToSlice())
+
+		a.NoError(p.FromNTT(pr))
+		a.False(p.IsNTT())
+		a.True(orig.Equals(p), "size=%d", size)
+
+		a.NoError(p.FromNTT(pr))
+		a.True(orig.Equals(p))
+	}
+
+	// a field without roots of unity leaves p in coefficient form.
+	f157, err := NewPrimeField(157)
+	a.NoError(err)
+
+	p := randomPolynomial(f157, 1, 64)
+	a.Error(p.ToNTT(NewDensePolyRing(f157)))
+	a.False(p.IsNTT())
+
+	a.ErrorIs(p.ToNTT(pr), ErrModulusMismatch)
+	a.ErrorIs(p.FromNTT(pr), ErrModulusMismatch)
+}
+
+func TestNewPolynomialChecked(t *testing.T) {
+	a := assert.New(t)
+
+	f, err := NewPrimeField(157)
+	a.NoError(err)
+
+	_, err = NewPolynomialChecked(f, nil, false)
+	a.ErrorIs(err, ErrEmptyPolynomial)
+
+	_, err = NewPolynomialChecked(f, []uint64{}, true)
+	a.ErrorIs(err, ErrEmptyPolynomial)
+
+	p, err := NewPolynomialChecked(f, []uint64{1, 156, 0}, false)
+	a.NoError(err)
+	a.Equal([]uint64{1, 156, 0}, p.ToSlice())
+	a.False(p.IsNTT())
+
+	// coefficients >= modulus are rejected, in either form.
+	_, err = NewPolynomialChecked(f, []uint64{1, 157}, false)
+	a.ErrorIs(err, ErrCoefficientNotReduced)
+
+	_, err = NewPolynomialChecked(f, []uint64{1, 2, 3, 1000}, true)
+	a.ErrorIs(err, ErrCoefficientNotReduced)
+
+	a.Panics(func() { NewPolynomial(f, nil, false) })
+}
+
+func TestCoeffsBigInt(t *testing.T) {
+	a := assert.New(t)
+
+	for _, prime := range []uint64{157, nttFriendlyLargePrime} {
+		f, err := NewPrimeField(prime)
+		a.NoError(err)
+
+		p := randomPolynomial(f, prime, 16)
+
+		q, err := SetCoeffsBigInt(f, p.CoeffsBigInt())
+		a.NoError(err)
+		a.Equal(p.ToSlice(), q.ToSlice())
+		a.False(q.IsNTT())
+
+		// values beyond uint64 are reduced.
+		big2to100 := new(big.Int).Lsh(big.NewInt(1), 100)
+		coeffs := []*big.Int{
+			big.NewInt(0),
+			new(big.Int).SetUint64(prime),
+			new(big.Int).SetUint64(prime + 1),
+			new(big.Int).SetUint64(math.MaxUint64),
+			big2to100,
+		}
+
+		q, err = SetCoeffsBigInt(f, coeffs)
+		a.NoError(err)
+
+		expected := new(big.Int).Mod(big2to100, new(big.Int).SetUint64(prime)).Uint64()
+		a.Equal([]uint64{0, 0, 1, math.MaxUint64 % prime, expected}, q.ToSlice())
+		a.Equal(big.NewInt(0), coeffs[0]) // not modified.
+
+		for i, c := range q.CoeffsBigInt() {
+			a.Equal(q.ToSlice()[i], c.Uint64())
+		}
+	}
+
+	f, err := NewPrimeField(157)
+	a.NoError(err)
+
+	_, err = SetCoeffsBigInt(f, []*big.Int{big.NewInt(1), big.NewInt(-1)})
+	a.ErrorIs(err, ErrNegativeCoefficient)
+
+	_, err = SetCoeffsBigInt(f, []*big.Int{nil})
+	a.ErrorIs(err, ErrNegativeCoefficient)
+
+	_, err = SetCoeffsBigInt(f, nil)
+	a.ErrorIs(err, ErrEmptyPolynomial)
+}
+
+func TestNewPolynomialReduced(t *testing.T) {
+	a := assert.New(t)
+
+	f, err := NewPrimeField(157)
+	a.NoError(err)
+
+	bf, err := NewBinaryField(4)
+	a.NoError(err)
+
+	p := NewPolynomialReduced(f, []uint64{1, 157, 158, 1000, math.MaxUint64}, false)
+	a.Equal([]uint64{1, 0, 1, 1000 % 157, math.MaxUint64 % 157}, p.ToSlice())
+
+	_, err = NewPolynomialChecked(f, p.ToSlice(), false)
+	a.NoError(err)
+
+	// the a == 0 fast path of Add sees the reduced 157.
+	pr := NewDensePolyRing(f)
+	sum := &Polynomial{}
+	pr.AddPoly(p, NewPolynomial(f, []uint64{0, 5}, false), sum)
+	a.Equal(uint64(5), sum.ToSlice()[1])
+
+	// fields without ElemSlice.
+	p = NewPolynomialReduced(bf, []uint64{3, 16, 17}, true)
+	for _, c := range p.ToSlice() {
+		a.Equal(bf.Reduce(c), c)
+	}
+
+	a.Panics(func() { NewPolynomialReduced(f, nil, false) })
+}
+
+func TestDeflate(t *testing.T) {
+	a := assert.New(t)
+
+	f, err := NewPrimeField(157)
+	a.NoError(err)
+
+	pr := NewDensePolyRing(f)
+
+	// p = (x - 3)^3 * (x - 5) * (x^2 + 1).
+	p := PolyProductMonicNegRoots(f, []uint64{3, 3, 3, 5})
+	rest := NewPolynomial(f, []uint64{1, 0, 1}, false)
+	pr.MulPoly(p, rest, p)
+	pCopy := p.Copy()
+
+	q, multiplicity := pr.Deflate(p, 3)
+	a.Equal(3, multiplicity)
+	a.True(p.Equals(pCopy)) // not modified.
+
+	expected := &Polynomial{}
+	pr.MulPoly(PolyProductMonicNegRoots(f, []uint64{5}), rest, expected)
+	a.True(q.Equals(expected))
+
+	q, multiplicity = pr.Deflate(q, 5+157) // unreduced root.
+	a.Equal(1, multiplicity)
+	a.True(q.Equals(rest))
+
+	// not a root.
+	q, multiplicity = pr.Deflate(p, 4)
+	a.Zero(multiplicity)
+	a.True(q.Equals(p))
+
+	// constants and x^m.
+	q, multiplicity = pr.Deflate(NewPolynomial(f, []uint64{7, 0}, false), 0)
+	a.Zero(multiplicity)
+	a.Equal([]uint64{7}, q.ToSlice())
+
+	q, multiplicity = pr.Deflate(NewPolynomial(f, []uint64{0, 0, 0, 2}, false), 0)
+	a.Equal(3, multiplicity)
+	a.Equal([]uint64{2}, q.ToSlice())
+
+	a.Panics(func() { pr.Deflate(NewPolynomial(f, []uint64{0, 0}, false), 1) })
+	a.Panics(func() { pr.Deflate(NewPolynomial(f, []uint64{1, 2}, true), 1) })
+}
+
+func TestDerivative(t *testing.T) {
+	a := assert.New(t)
+
+	f, err := NewPrimeField(157)
+	a.NoError(err)
+
+	pr := NewDensePolyRing(f)
+
+	// (3 + 5x + 7x^2 + 2x^4)' = 5 + 14x + 8x^3.
+	p := NewPolynomial(f, []uint64{3, 5, 7, 0, 2}, false)
+	deriv := &Polynomial{}
+	pr.Derivative(p, deriv)
+	a.Equal([]uint64{5, 14, 0, 8}, deriv.ToSlice())
+	a.True(FromDense(p).Derivative().ToDense().Equals(deriv))
+
+	// product rule: (pq)' = p'q + pq'.
+	q := randomPolynomial(f, 7, 10)
+	pq, pq1, p1q, qDeriv, sum := &Polynomial{}, &Polynomial{}, &Polynomial{}, &Polynomial{}, &Polynomial{}
+	pr.MulPoly(p, q, pq)
+	pr.Derivative(pq, pq)
+	pr.Derivative(q, qDeriv)
+	pr.MulPoly(deriv, q, p1q)
+	pr.MulPoly(p, qDeriv, pq1)
+	pr.AddPoly(p1q, pq1, sum)
+	a.True(sum.Equals(pq))
+
+	// in place, and constants.
+	pr.Derivative(p, p)
+	a.True(p.Equals(deriv))
+
+	c := NewPolynomial(f, []uint64{42}, false)
+	pr.Derivative(c, c)
+	a.True(c.IsZero())
+	a.True(NewSparsePolynomial(f, []SparseTerm{{Degree: 0, Coeff: 42}}).Derivative().IsZero())
+
+	// in characteristic 2, (x^2 + x)' = 2x + 1 = 1.
+	bf, err := NewBinaryField(4)
+	a.NoError(err)
+
+	bDeriv := &Polynomial{}
+	NewDensePolyRing(bf).Derivative(NewPolynomial(bf, []uint64{0, 1, 1}, false), bDeriv)
+	a.Equal([]uint64{1}, bDeriv.ToSlice())
+
+	a.Panics(func() { pr.Derivative(NewPolynomial(f, []uint64{1, 2}, true), &Polynomial{}) })
+}
+
+func TestNormalize(t *testing.T) {
+	a := assert.New(t)
+
+	f, err := NewPrimeField(65537)
+	a.NoError(err)
+
+	pr := NewDensePolyRing(f)
+
+	// gcd(p1*h, p2*h) = h up to a scalar, for coprime p1 and p2.
+	h := NewPolynomial(f, []uint64{5, 3, 2}, false)
+	p1 := NewPolynomial(f, []uint64{1, 1}, false)
+	p2 := NewPolynomial(f, []uint64{2, 0, 7}, false)
+
+	p1h, p2h, p2h3 := &Polynomial{}, &Polynomial{}, &Polynomial{}
+	pr.MulPoly(p1, h, p1h)
+	pr.MulPoly(p2, h, p2h)
+	pr.MulScalar(p2h, 3, p2h3)
+
+	gcd1, _, _ := pr.PartialExtendedEuclidean(p1h, p2h, 0)
+	gcd2, _, _ := pr.PartialExtendedEuclidean(p2h3, p1h, 0)
+	a.Equal(2, gcd1.Degree())
+	a.False(gcd1.Equals(gcd2))
+
+	norm1, norm2 := &Polynomial{}, &Polynomial{}
+	lead1 := pr.Normalize(gcd1, norm1)
+	pr.Normalize(gcd2, norm2)
+
+	a.True(norm1.IsMonic())
+	a.True(norm1.Equals(norm2))
+
+	monicH, _ := pr.Monic(h)
+	a.True(monicH.Equals(norm1))
+
+	// gcd1 = lead * norm1.
+	scaled := &Polynomial{}
+	pr.MulScalar(norm1, lead1, scaled)
+	a.True(scaled.Equals(gcd1))
+
+	// in place.
+	lead := pr.Normalize(gcd1, gcd1)
+	a.Equal(lead1, lead)
+	a.True(gcd1.Equals(norm1))
+
+	// the zero polynomial.
+	zero := NewPolynomial(f, []uint64{0, 0}, false)
+	a.Zero(pr.Normalize(zero, zero))
+	a.True(zero.IsZero())
+
+	a.Panics(func() { pr.Normalize(NewPolynomial(f, []uint64{1, 2}, true), &Polynomial{}) })
+}
+
+func TestIsMonicAndZeroPolynomial(t *testing.T) {
+	a := assert.New(t)
+
+	f, err := NewPrimeField(157)
+	a.NoError(err)
+
+	a.True(NewPolynomial(f, []uint64{3, 1}, false).IsMonic())
+	a.True(NewPolynomial(f, []uint64{1, 0, 0}, false).IsMonic()) // the constant 1.
+	a.True(NewPolynomial(f, []uint64{3, 158}, false).IsMonic())  // unreduced 1.
+	a.False(NewPolynomial(f, []uint64{1, 2}, false).IsMonic())
+
+	monic, _ := NewDensePolyRing(f).Monic(NewPolynomial(f, []uint64{4, 5, 6, 0}, false))
+	a.True(monic.IsMonic())
+
+	// the zero polynomial, with any number of zero coefficients.
+	for _, zero := range []*Polynomial{
+		NewPolynomial(f, []uint64{0}, false),
+		NewPolynomial(f, []uint64{0, 0, 0}, false),
+	} {
+		a.True(zero.IsZero())
+		a.False(zero.IsMonic())
+		a.Zero(zero.LeadCoeff())
+		a.Negative(zero.Degree())
+	}
+}
+
+func TestDegreeOfZero(t *testing.T) {
+	a := assert.New(t)
+
+	f, err := NewPrimeField(65537)
+	a.NoError(err)
+
+	pr := NewDensePolyRing(f)
+
+	zero := NewPolynomial(f, []uint64{0, 0}, false)
+	a.Equal(-1, zero.Degree())
+	a.Equal(DegreeOfZero, zero.Degree())
+	a.Equal(0, zero.Degree()+1) // safe to do arithmetic with.
+	a.Equal(DegreeOfZero, (&Polynomial{f: f}).Degree())
+	a.Equal(DegreeOfZero, NewSparsePolynomial(f, nil).Degree())
+
+	// long division: the zero polynomial has a smaller degree than any divisor.
+	b := NewPolynomial(f, []uint64{1, 2, 3}, false)
+	q, rem := pr.LongDiv(zero, b)
+	a.True(q.IsZero())
+	a.Equal(DegreeOfZero, rem.Degree())
+
+	// b*(x+1) is divisible by b, thus the remainder is zero.
+	bx1 := &Polynomial{}
+	pr.MulPoly(b, NewPolynomial(f, []uint64{1, 1}, false), bx1)
+	_, rem = pr.LongDiv(bx1, b)
+	a.Equal(DegreeOfZero, rem.Degree())
+
+	// EEA terminates once the remainder is zero, even if the stop degree is never reached.
+	for _, stopDegree := range []int{0, DegreeOfZero} {
+		gcd, x, y := pr.PartialExtendedEuclidean(bx1, b, stopDegree)
+		a.Equal(2, gcd.Degree())
+
+		ax, by, ax_plus_by := &Polynomial{}, &Polynomial{}, &Polynomial{}
+		pr.MulPoly(bx1, x, ax)
+		pr.MulPoly(b, y, by)
+		pr.AddPoly(ax, by, ax_plus_by)
+		a.True(ax_plus_by.Equals(gcd))
+	}
+
+	// EEA with a zero input.
+	gcd, _, _ := pr.PartialExtendedEuclidean(zero, b, DegreeOfZero)
+	a.True(gcd.Equals(b))
+}
+
+// a 62-bit prime of the form c*2^32+1, supporting NTTs up to size 2^32.
+const nttFriendlyLargePrime = 4611685318347718657
+
+func TestMulThresholdOption(t *testing.T) {
+	a := assert.New(t)
+
+	f, err := NewPrimeField(65537)
+	a.NoError(err)
+
+	alwaysNtt := NewDensePolyRing(f, WithMulThreshold(0))
+	neverNtt := NewDensePolyRing(f, WithMulThreshold(math.MaxInt))
+
+	p1 := randomPolynomial(f, 12345, 300)
+	p2 := randomPolynomial(f, 67890, 299)
+
+	c1, c2 := &Polynomial{}, &Polynomial{}
+	alwaysNtt.(*DensePolyRing).mulFull(p1, p2, c1)
+	neverNtt.(*DensePolyRing).mulFull(p1, p2, c2)
+	a.True(c1.Equals(c2))
+
+	g1, x1, y1 := alwaysNtt.NttPartialExtendedEuclidean(p1, p2, 100)
+	g2, x2, y2 := neverNtt.NttPartialExtendedEuclidean(p1, p2, 100)
+	a.True(g1.Equals(g2))
+	a.True(x1.Equals(x2))
+	a.True(y1.Equals(y2))
+}
+
+/*
+The crossover between schoolbook and NTT multiplication depends on the prime:
+compare the "ntt" and "schoolbook" runs per size to find it.
+*/
+func BenchmarkMulThreshold(b *testing.B) {
+	for _, prime := range []uint64{65537, nttFriendlyLargePrime} {
+		f, err := NewPrimeField(prime)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		rings := []struct {
+			name string
+			pr   *DensePolyRing
+		}{
+			{"ntt", NewDensePolyRing(f, WithMulThreshold(0)).(*DensePolyRing)},
+			{"schoolbook", NewDensePolyRing(f, WithMulThreshold(math.MaxInt)).(*DensePolyRing)},
+		}
+
+		for _, size := range []int{16, 32, 64, 128, 256} {
+			p1 := randomPolynomial(f, prime/4, size)
+			p2 := randomPolynomial(f, prime/7, size)
+
+			for _, r := range rings {
+				b.Run(fmt.Sprintf("p=%d/size=%d/%s", prime, size, r.name), func(b *testing.B) {
+					c := &Polynomial{}
+					b.ResetTimer()
+					for i := 0; i < b.N; i++ {
+						r.pr.mulFull(p1, p2, c)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestDivThresholdOption(t *testing.T) {
+	a := assert.New(t)
+
+	rnd := rand.New(rand.NewSource(1))
+
+	for _, prime := range []uint64{65537, largePrime} {
+		f, err := NewPrimeField(prime)
+		a.NoError(err)
+
+		alwaysNtt := NewDensePolyRing(f, WithMulThreshold(0), WithDivThreshold(0))
+		neverNtt := NewDensePolyRing(f, WithDivThreshold(math.MaxInt))
+
+		// long quotients early on, then short ones.
+		p1 := randomPolynomialWithTrailingZeros(f, rnd, 600, 0)
+		p2 := randomPolynomialWithTrailingZeros(f, rnd, 300, 0)
+
+		g1, x1, y1 := alwaysNtt.NttPartialExtendedEuclidean(p1, p2, 50)
+		g2, x2, y2 := neverNtt.NttPartialExtendedEuclidean(p1, p2, 50)
+		a.True(g1.Equals(g2))
+		a.True(x1.Equals(x2))
+		a.True(y1.Equals(y2))
+
+		g3, x3, y3 := alwaysNtt.PartialExtendedEuclidean(p1, p2, 50)
+		a.True(g1.Equals(g3))
+		a.True(x1.Equals(x3))
+		a.True(y1.Equals(y3))
+	}
+}
+
+/*
+The crossover between LongDiv and LongDivNTT depends on the quotient's length k far more than on the divisor's
+length m. For p=65537 (ns/op):
+
+	m=256,  k=2:   LongDiv 14516    LongDivNTT 140703
+	m=256,  k=128: LongDiv 837979   LongDivNTT 863212
+	m=1024, k=32:  LongDiv 616522   LongDivNTT 910176
+	m=1024, k=128: LongDiv 3081636  LongDivNTT 1200842
+	m=4096, k=2:   LongDiv 175121   LongDivNTT 2604436
+	m=4096, k=128: LongDiv 9528057  LongDivNTT 3844488
+*/
+func BenchmarkDivThreshold(b *testing.B) {
+	for _, prime := range []uint64{65537, nttFriendlyLargePrime} {
+		f, err := NewPrimeField(prime)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		pr := NewDensePolyRing(f)
+		rnd := rand.New(rand.NewSource(1))
+
+		for _, m := range []int{256, 1024, 4096} {
+			for _, k := range []int{2, 32, 128, 512} {
+				p1 := randomPolynomialWithTrailingZeros(f, rnd, m+k-1, 0)
+				p2 := randomPolynomialWithTrailingZeros(f, rnd, m, 0)
+
+				b.Run(fmt.Sprintf("p=%d/m=%d/k=%d/LongDiv", prime, m, k), func(b *testing.B) {
+					for i := 0; i < b.N; i++ {
+						pr.LongDiv(p1, p2)
+					}
+				})
+
+				b.Run(fmt.Sprintf("p=%d/m=%d/k=%d/LongDivNTT", prime, m, k), func(b *testing.B) {
+					for i := 0; i < b.N; i++ {
+						pr.LongDivNTT(p1, p2)
+					}
+				})
+			}
+		}
+	}
+}
+
+// primes with 2-adicity of at least 8.
+var nttFriendlyPrimes = []uint64{3329, 7681, 12289, 65537, 998244353, nttFriendlyLargePrime}
+
+// randomPolynomialWithTrailingZeros creates a polynomial of degree deg, padded with `pad` zero coefficients.
+func randomPolynomialWithTrailingZeros(f Field, rnd *rand.Rand, deg, pad int) *Polynomial {
+	coeffs := make([]uint64, deg+1+pad)
+	for i := 0; i <= deg; i++ {
+		coeffs[i] = rnd.Uint64() % f.Modulus()
+	}
+
+	coeffs[deg] = rnd.Uint64()%(f.Modulus()-1) + 1 // non-zero leading coefficient.
+
+	return NewPolynomial(f, coeffs, false)
+}
+
+func FuzzLongDivNTT(f *testing.F) {
+	f.Add(uint64(1), uint16(16), uint16(8), uint8(0), uint8(0))
+	f.Add(uint64(2), uint16(1024), uint16(512), uint8(0), uint8(0))
+	f.Add(uint64(3), uint16(300), uint16(299), uint8(0), uint8(0))
+	f.Add(uint64(4), uint16(300), uint16(298), uint8(1), uint8(3))
+	f.Add(uint64(5), uint16(7), uint16(10), uint8(0), uint8(0))
+	f.Add(uint64(6), uint16(64), uint16(0), uint8(2), uint8(2))
+	f.Add(uint64(7), uint16(511), uint16(256), uint8(0), uint8(5))
+	f.Add(uint64(6), uint16(229), uint16(206), uint8(39), uint8(101)) // q(0) = 0.
+
+	rings := make([]PolyRing, len(nttFriendlyPrimes))
+	// LongDivNTT uses NTTs of size up to 2*deg(a), so degrees are bounded by half the largest NTT size.
+	maxDegrees := make([]int, len(nttFriendlyPrimes))
+	for i, p := range nttFriendlyPrimes {
+		fld, err := NewPrimeField(p)
+		if err != nil {
+			f.Fatal(err)
+		}
+
+		rings[i] = NewDensePolyRing(fld)
+
+		maxDegrees[i] = 2048
+		for (p-1)%uint64(2*maxDegrees[i]) != 0 {
+			maxDegrees[i] >>= 1
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, seed uint64, degA, degB uint16, padA, padB uint8) {
+		rnd := rand.New(rand.NewSource(int64(seed)))
+
+		i := seed % uint64(len(rings))
+		pr, maxDeg := rings[i], maxDegrees[i]
+		fld := pr.GetField()
+
+		a := randomPolynomialWithTrailingZeros(fld, rnd, int(degA)%maxDeg, int(padA%8))
+		b := randomPolynomialWithTrailingZeros(fld, rnd, int(degB)%maxDeg, int(padB%8))
+
+		q1, r1 := pr.LongDiv(a.Copy(), b.Copy())
+		q2, r2 := pr.LongDivNTT(a.Copy(), b.Copy())
+
+		if !q1.Equals(q2) || !r1.Equals(r2) {
+			t.Fatalf("p=%d deg(a)=%d deg(b)=%d: LongDiv and LongDivNTT disagree", fld.Modulus(), a.Degree(), b.Degree())
+		}
+
+		// a = q*b + r, deg(r) < deg(b)
+		qb, qbr := &Polynomial{}, &Polynomial{}
+		pr.MulPoly(q2, b, qb)
+		pr.AddPoly(qb, r2, qbr)
+
+		if !qbr.Equals(a) || r2.Degree() >= b.Degree() {
+			t.Fatalf("p=%d deg(a)=%d deg(b)=%d: invalid division", fld.Modulus(), a.Degree(), b.Degree())
+		}
+	})
+}
+
+func TestLongDivByZeroPolynomial(t *testing.T) {
+	a := assert.New(t)
+
+	f, err := NewPrimeField(5)
+	a.NoError(err)
+
+	pr := NewDensePolyRing(f)
+
+	p := NewPolynomial(f, []uint64{1, 2, 3}, false)
+
+	for _, zero := range []*Polynomial{
+		NewPolynomial(f, []uint64{0}, false),
+		NewPolynomial(f, []uint64{0, 0, 0}, false),
+		NewPolynomial(f, []uint64{5}, false), // unreduced zero.
+	} {
+		q, r, err := pr.TryLongDiv(p, zero)
+		a.ErrorIs(err, ErrDivideByZeroPolynomial)
+		a.Nil(q)
+		a.Nil(r)
+
+		q, r = pr.LongDiv(p, zero)
+		a.Nil(q)
+		a.Nil(r)
+
+		q, r = pr.LongDivNTT(p, zero)
+		a.Nil(q)
+		a.Nil(r)
+	}
+
+	_, _, err = pr.TryLongDiv(p, NewPolynomial(f, []uint64{1, 2}, true))
+	a.Error(err)
+
+	q, r, err := pr.TryLongDiv(p, NewPolynomial(f, []uint64{1, 2, 3}, false))
+	a.NoError(err)
+	a.Equal([]uint64{1}, q.ToSlice())
+	a.True(r.IsZero())
+}
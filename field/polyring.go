@@ -1,32 +1,82 @@
 package field
 
-import "sync"
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+)
 
 type PolyRing interface {
 	Field
 	GetField() Field
 
 	Evaluate(a *Polynomial, x uint64) uint64
+	// Evaluate that also accepts polynomials in NTT form.
+	EvaluateAny(a *Polynomial, x uint64) uint64
+	// evaluates a at every x in xs, via an NTT when xs are powers of a root of unity.
+	EvaluateDomain(a *Polynomial, xs []uint64) ([]uint64, error)
 	// compute c = a * scalar
 	MulScalar(a *Polynomial, scalar uint64, c *Polynomial)
+	// compute a = a * scalar, reducing scalar once.
+	MulScalarInPlace(a *Polynomial, scalar uint64)
+	// returns the monic form of a, and the leading coefficient that was factored out.
+	Monic(a *Polynomial) (*Polynomial, uint64)
+	// compute c = a / LeadCoeff(a), possibly in place, returning the leading coefficient.
+	Normalize(a, c *Polynomial) uint64
+	// compute c = a', the formal derivative of a.
+	Derivative(a, c *Polynomial)
+	// divides a by (x - root) as many times as it divides it.
+	Deflate(a *Polynomial, root uint64) (quotient *Polynomial, multiplicity int)
+	// returns the distinct roots of a in the field, in ascending order.
+	Roots(a *Polynomial) []uint64
 
 	// compute c = a * b
 	MulPoly(a, b, c *Polynomial)
+	// compute c = a * b, splitting the schoolbook convolution across workers.
+	MulPolyParallel(a, b, c *Polynomial, workers int)
 	// compute c = a + b
 	AddPoly(a, b, c *Polynomial)
 	// compute c = a - b
 	SubPoly(a, b, c *Polynomial)
+	// AddPoly, SubPoly and MulPoly that return an error on mismatched operands instead of panicking.
+	TryAddPoly(a, b, c *Polynomial) error
+	TrySubPoly(a, b, c *Polynomial) error
+	TryMulPoly(a, b, c *Polynomial) error
 
 	// Creates quotient and remainder
 	LongDiv(a, b *Polynomial) (q *Polynomial, r *Polynomial) // returns quotient, remainder
 	LongDivNTT(a, b *Polynomial) (q, r *Polynomial)          // returns quotient, remainder
+	// LongDiv that returns an error (e.g., ErrDivideByZeroPolynomial) instead of (nil, nil).
+	TryLongDiv(a, b *Polynomial) (q, r *Polynomial, err error)
 
 	// Extended Euclidean algorithm.
 	PartialExtendedEuclidean(a, b *Polynomial, stopDegree int) (gcd, x, y *Polynomial)
 	NttPartialExtendedEuclidean(a, b *Polynomial, stopDegree int) (gcd, x, y *Polynomial)
-
+	// PartialExtendedEuclidean reporting the degrees after each step to onStep.
+	PartialExtendedEuclideanTraced(a, b *Polynomial, stopDegree int, onStep func(iter int, remDeg, xDeg, yDeg int)) (gcd, x, y *Polynomial)
+	// cancellable PartialExtendedEuclidean and NttPartialExtendedEuclidean, returning ctx.Err() once ctx is done.
+	PartialExtendedEuclideanContext(ctx context.Context, a, b *Polynomial, stopDegree int) (gcd, x, y *Polynomial, err error)
+	NttPartialExtendedEuclideanContext(ctx context.Context, a, b *Polynomial, stopDegree int) (gcd, x, y *Polynomial, err error)
+
+	// zero-extend a to a power-of-two length, into a copy or in place.
+	PadToPowerOfTwo(a *Polynomial) *Polynomial
+	PadToPowerOfTwoInPlace(a *Polynomial)
 	// Assumes it is a polynomial of a valid degree.
 	NttForward(a *Polynomial) error
+	// NTT of src into a caller-owned dst, zero-padding src to len(dst).
+	NttForwardInto(dst, src []uint64) error
+	// in-place transforms of raw slices of power-of-two length.
+	NttForwardSlice(xs []uint64) error
+	NttBackwardSlice(xs []uint64) error
+	// cache-friendly NttForwardSlice for large inputs.
+	NttForwardFourStep(xs []uint64) error
+	// NttForwardSlice without the bit-reversal permutation.
+	NttForwardStockham(xs []uint64) error
+
+	// a*b mod (x^n - 1) and a*b mod (x^n + 1), for power-of-two n.
+	CyclicConvolve(a, b []uint64, n int) ([]uint64, error)
+	NegacyclicConvolve(a, b []uint64, n int) ([]uint64, error)
 	NttBackward(a *Polynomial) error
 }
 
@@ -35,31 +85,112 @@ type DensePolyRing struct {
 	Field
 	mu           sync.RWMutex
 	twiddleCache map[int]*twiddleSet // key: n
+
+	// ~coeff count where NTT multiplication starts winning over schoolbook.
+	mulThreshold int
+	// transform size from which forward NTTs use NttForwardFourStep.
+	fourStepThreshold int
+	// quotient length from which the EEA loops divide with LongDivNTT.
+	divThreshold int
+	// allocate coefficient buffers at bufferAlignment, see WithAlignedBuffers.
+	aligned bool
+}
+
+// PolyRingOption configures a DensePolyRing on construction.
+type PolyRingOption func(*DensePolyRing)
+
+// WithMulThreshold sets the coefficient count from which NTT-based multiplication and division
+// are used instead of the schoolbook variants. The crossover depends on the prime size and hardware.
+func WithMulThreshold(n int) PolyRingOption {
+	return func(r *DensePolyRing) {
+		r.mulThreshold = n
+	}
+}
+
+// WithDivThreshold sets the quotient length from which the EEA loops divide with LongDivNTT instead of LongDiv.
+// Schoolbook division costs O(len(q)*len(b)), thus it wins on short quotients regardless of the inputs' size.
+func WithDivThreshold(n int) PolyRingOption {
+	return func(r *DensePolyRing) {
+		r.divThreshold = n
+	}
+}
+
+// WithFourStepThreshold sets the transform size from which forward NTTs use NttForwardFourStep.
+// It is off by default, see defaultFourStepThreshold.
+func WithFourStepThreshold(n int) PolyRingOption {
+	return func(r *DensePolyRing) {
+		r.fourStepThreshold = n
+	}
+}
+
+// WithAlignedBuffers allocates the coefficient buffers of the ring's results, and the scratch buffers
+// of its NTTs, at 64-byte (cache line) boundaries, a layout SIMD butterflies can rely on.
+// It doesn't change any result. Buffers passed in by the caller are reused as they are.
+func WithAlignedBuffers() PolyRingOption {
+	return func(r *DensePolyRing) {
+		r.aligned = true
+	}
 }
 
 // NewDensePolyRing constructs a ring over the provided coefficient field.
-func NewDensePolyRing(f Field) PolyRing {
-	return &DensePolyRing{
+func NewDensePolyRing(f Field, opts ...PolyRingOption) PolyRing {
+	r := &DensePolyRing{
 		Field:        f,
 		mu:           sync.RWMutex{},
 		twiddleCache: map[int]*twiddleSet{},
+		mulThreshold: defaultNttMulThreshold,
+
+		fourStepThreshold: defaultFourStepThreshold,
+		divThreshold:      defaultNttDivThreshold,
+	}
+
+	for _, opt := range opts {
+		opt(r)
 	}
+
+	return r
 }
 
 func (r *DensePolyRing) GetField() Field { return r.Field }
 
 // ---------- utilities ----------
 
-func ensureLen(c *Polynomial, n int) {
-	if len(c.inner) < n {
-		tmp := make([]uint64, n)
+// makeCoeffs returns a zeroed buffer of n coefficients, aligned if the ring was built WithAlignedBuffers.
+func (r *DensePolyRing) makeCoeffs(n int) []uint64 {
+	if r.aligned {
+		return makeAligned(n)
+	}
+
+	return make([]uint64, n)
+}
+
+func (r *DensePolyRing) ensureLen(c *Polynomial, n int) {
+	switch {
+	case len(c.inner) >= n:
+		c.inner = c.inner[:n]
+	case cap(c.inner) >= n:
+		// reuse capacity, zeroing the newly exposed coefficients.
+		l := len(c.inner)
+		c.inner = c.inner[:n]
+		clear(c.inner[l:])
+	default:
+		tmp := r.makeCoeffs(n)
 		copy(tmp, c.inner)
 		c.inner = tmp
-	} else {
-		c.inner = c.inner[:n]
 	}
 }
 
+// isZeroPoly reports whether all of p's coefficients are zero in the field.
+func (r *DensePolyRing) isZeroPoly(p *Polynomial) bool {
+	for _, c := range p.inner {
+		if !r.Equals(c, 0) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (r *DensePolyRing) trimTrailingZeros(p *Polynomial) {
 	if len(p.inner) == 0 || p.isNTT {
 		// In NTT domain we keep the fixed size.
@@ -90,11 +221,28 @@ func (r *DensePolyRing) Evaluate(a *Polynomial, x uint64) uint64 {
 	return result
 }
 
+// EvaluateAny evaluates a at x, where a can be in either coefficient or NTT form.
+// An NTT-form polynomial is copied and transformed back first, costing an extra O(n log n)
+// and O(n) memory on top of Horner's O(n). a is not mutated.
+// Panics if a is in NTT form and its length is not a power of two.
+func (r *DensePolyRing) EvaluateAny(a *Polynomial, x uint64) uint64 {
+	if !a.isNTT {
+		return r.Evaluate(a, x)
+	}
+
+	cpy := a.Copy()
+	if err := r.nttBackwardNoTrim(cpy); err != nil {
+		panic(err)
+	}
+
+	return r.Evaluate(cpy, x)
+}
+
 func (r *DensePolyRing) MulScalar(a *Polynomial, scalar uint64, c *Polynomial) {
 	s := r.Reduce(scalar)
 	f := r.GetField()
 
-	ensureLen(c, len(a.inner))
+	r.ensureLen(c, len(a.inner))
 	for i := range a.inner {
 		c.inner[i] = f.Mul(a.inner[i], s)
 	}
@@ -105,31 +253,194 @@ func (r *DensePolyRing) MulScalar(a *Polynomial, scalar uint64, c *Polynomial) {
 	r.trimTrailingZeros(c)
 }
 
+// MulScalarInPlace is MulScalar(a, scalar, a): it reduces scalar by the ring's field once, and trims a.
+func (r *DensePolyRing) MulScalarInPlace(a *Polynomial, scalar uint64) {
+	r.MulScalar(a, scalar, a)
+}
+
+// Monic returns a / LeadCoeff(a) and the factored out leading coefficient,
+// such that a = lead * monic. Panics on the zero polynomial or NTT inputs.
+func (r *DensePolyRing) Monic(a *Polynomial) (*Polynomial, uint64) {
+	if a.isNTT {
+		panic("Monic not supported in NTT domain")
+	}
+
+	lead := r.Reduce(a.LeadCoeff())
+	if lead == 0 {
+		panic("zero polynomial has no monic form")
+	}
+
+	if lead == 1 {
+		monic := a.Copy()
+		r.trimTrailingZeros(monic)
+
+		return monic, 1
+	}
+
+	monic := &Polynomial{f: r.Field}
+	r.MulScalar(a, r.Inverse(lead), monic)
+
+	return monic, lead
+}
+
+// Normalize sets c to the monic associate of a, i.e., a / LeadCoeff(a), and returns the leading coefficient,
+// such that a = lead * c. c may alias a, normalizing it in place. Canonicalizes EEA outputs, e.g.,
+// gcds that differ by a scalar normalize to the same polynomial.
+// Unlike Monic, the zero polynomial is accepted: c is set to zero and the returned lead is 0.
+// Panics on NTT inputs.
+func (r *DensePolyRing) Normalize(a, c *Polynomial) uint64 {
+	if a.isNTT {
+		panic("Normalize not supported in NTT domain")
+	}
+
+	lead := r.Reduce(a.LeadCoeff())
+	if lead == 0 {
+		r.MulScalar(a, 0, c)
+
+		return 0
+	}
+
+	r.MulScalar(a, r.Inverse(lead), c)
+
+	return lead
+}
+
+// Derivative sets c to the formal derivative of a, \sum i*a_i*x^(i-1). c may alias a.
+// The integer multiples i*a_i are taken in the field, so they work in any characteristic.
+// Panics on NTT inputs.
+func (r *DensePolyRing) Derivative(a, c *Polynomial) {
+	if a.isNTT {
+		panic("Derivative not supported in NTT domain")
+	}
+
+	src := a.inner // ensureLen may shrink a.inner when c aliases a.
+
+	n := len(src)
+	if n <= 1 {
+		r.ensureLen(c, 1)
+		c.inner[0] = 0
+	} else {
+		r.ensureLen(c, n-1)
+
+		// ascending order, thus src[i] is read before c.inner[i] overwrites it when c aliases a.
+		for i := 1; i < n; i++ {
+			c.inner[i-1] = mulInt(r.Field, src[i], uint64(i))
+		}
+	}
+
+	c.f = r.Field
+	c.isNTT = false
+
+	r.trimTrailingZeros(c)
+}
+
+// Deflate divides a by (x - root) for as long as the division is exact, returning the fully deflated quotient
+// and the multiplicity of root, i.e., a = (x - root)^multiplicity * quotient with quotient(root) != 0.
+// a is not modified. Panics on the zero polynomial, which every root divides infinitely often, or NTT inputs.
+func (r *DensePolyRing) Deflate(a *Polynomial, root uint64) (quotient *Polynomial, multiplicity int) {
+	if a.isNTT {
+		panic("Deflate not supported in NTT domain")
+	}
+
+	if r.isZeroPoly(a) {
+		panic("zero polynomial can't be deflated")
+	}
+
+	root = r.Reduce(root)
+	coeffs := NewPolynomialReduced(r.Field, a.ToSlice(), false).Trim().inner
+	buf := make([]uint64, len(coeffs)-1)
+
+	for len(coeffs) > 1 {
+		// synthetic division: q_{i-1} = c_i + root*q_i, and the remainder is c_0 + root*q_0 = a(root).
+		q := buf[:len(coeffs)-1]
+		q[len(q)-1] = coeffs[len(coeffs)-1]
+
+		for i := len(q) - 1; i > 0; i-- {
+			q[i-1] = r.Add(coeffs[i], r.Mul(root, q[i]))
+		}
+
+		if r.Add(coeffs[0], r.Mul(root, q[0])) != 0 {
+			break
+		}
+
+		coeffs, buf = q, coeffs
+		multiplicity++
+	}
+
+	quotient = NewPolynomial(r.Field, coeffs, false)
+
+	return quotient, multiplicity
+}
+
+// mulInt returns c added to itself m times, which is c*m in fields of any characteristic.
+func mulInt(f Field, c, m uint64) uint64 {
+	res := uint64(0)
+	for ; m > 0; m >>= 1 {
+		if m&1 == 1 {
+			res = f.Add(res, c)
+		}
+
+		c = f.Add(c, c)
+	}
+
+	return res
+}
+
 func (r *DensePolyRing) AddPoly(a, b, c *Polynomial) {
-	if !preOpVerification(a, b) {
-		panic("preOpVerification failed")
+	if err := r.TryAddPoly(a, b, c); err != nil {
+		panic(err)
 	}
+}
 
+// TryAddPoly is AddPoly, reporting mismatched operands (ErrModulusMismatch, ErrDomainMismatch
+// or ErrNttLengthMismatch) as errors instead of panicking. c is untouched on error.
+func (r *DensePolyRing) TryAddPoly(a, b, c *Polynomial) error {
+	if err := verifyOperands(a, b); err != nil {
+		return err
+	}
+
+	r.addPoly(a, b, c)
+
+	return nil
+}
+
+func (r *DensePolyRing) addPoly(a, b, c *Polynomial) {
 	alen := len(a.inner)
 	blen := len(b.inner)
 	n := max(alen, blen)
-	ensureLen(c, n)
+	r.ensureLen(c, n)
 
-	var av, bv uint64
-	for i := 0; i < n; i++ {
-		if i < alen {
-			av = r.Reduce(a.inner[i])
-		} else {
-			av = 0
-		}
+	// a devirtualized loop over prime fields, reducing with ReduceBarrett instead of dividing.
+	if pf, ok := r.Field.(*PrimeField); ok {
+		for i := 0; i < n; i++ {
+			var av, bv uint64
+			if i < alen {
+				av = pf.reduceBarrettLazy(a.inner[i])
+			}
 
-		if i < blen {
-			bv = r.Reduce(b.inner[i])
-		} else {
-			bv = 0
-		}
+			if i < blen {
+				bv = pf.reduceBarrettLazy(b.inner[i])
+			}
 
-		c.inner[i] = r.Add(av, bv)
+			c.inner[i] = pf.Add(av, bv)
+		}
+	} else {
+		var av, bv uint64
+		for i := 0; i < n; i++ {
+			if i < alen {
+				av = r.Reduce(a.inner[i])
+			} else {
+				av = 0
+			}
+
+			if i < blen {
+				bv = r.Reduce(b.inner[i])
+			} else {
+				bv = 0
+			}
+
+			c.inner[i] = r.Add(av, bv)
+		}
 	}
 
 	c.f = r.Field
@@ -138,30 +449,60 @@ func (r *DensePolyRing) AddPoly(a, b, c *Polynomial) {
 }
 
 func (r *DensePolyRing) SubPoly(a, b, c *Polynomial) {
-	if !preOpVerification(a, b) {
-		panic("preOpVerification failed")
+	if err := r.TrySubPoly(a, b, c); err != nil {
+		panic(err)
+	}
+}
+
+// TrySubPoly is SubPoly, reporting mismatched operands (ErrModulusMismatch, ErrDomainMismatch
+// or ErrNttLengthMismatch) as errors instead of panicking. c is untouched on error.
+func (r *DensePolyRing) TrySubPoly(a, b, c *Polynomial) error {
+	if err := verifyOperands(a, b); err != nil {
+		return err
 	}
 
+	r.subPoly(a, b, c)
+
+	return nil
+}
+
+func (r *DensePolyRing) subPoly(a, b, c *Polynomial) {
 	alen := len(a.inner)
 	blen := len(b.inner)
 	n := max(alen, blen)
-	ensureLen(c, n)
+	r.ensureLen(c, n)
 
-	var av, bv uint64
-	for i := 0; i < n; i++ {
-		if i < alen {
-			av = r.Reduce(a.inner[i])
-		} else {
-			av = 0
-		}
+	// a devirtualized loop over prime fields, reducing with ReduceBarrett instead of dividing.
+	if pf, ok := r.Field.(*PrimeField); ok {
+		for i := 0; i < n; i++ {
+			var av, bv uint64
+			if i < alen {
+				av = pf.reduceBarrettLazy(a.inner[i])
+			}
 
-		if i < blen {
-			bv = r.Reduce(b.inner[i])
-		} else {
-			bv = 0
-		}
+			if i < blen {
+				bv = pf.reduceBarrettLazy(b.inner[i])
+			}
 
-		c.inner[i] = r.Sub(av, bv)
+			c.inner[i] = pf.Sub(av, bv)
+		}
+	} else {
+		var av, bv uint64
+		for i := 0; i < n; i++ {
+			if i < alen {
+				av = r.Reduce(a.inner[i])
+			} else {
+				av = 0
+			}
+
+			if i < blen {
+				bv = r.Reduce(b.inner[i])
+			} else {
+				bv = 0
+			}
+
+			c.inner[i] = r.Sub(av, bv)
+		}
 	}
 
 	c.f = r.Field
@@ -171,14 +512,28 @@ func (r *DensePolyRing) SubPoly(a, b, c *Polynomial) {
 }
 
 func (r *DensePolyRing) MulPoly(a, b, c *Polynomial) {
-	if !preOpVerification(a, b) {
-		panic("preOpVerification failed")
+	if err := r.TryMulPoly(a, b, c); err != nil {
+		panic(err)
 	}
+}
+
+// TryMulPoly is MulPoly, reporting mismatched operands (ErrModulusMismatch, ErrDomainMismatch
+// or ErrNttLengthMismatch) as errors instead of panicking. c is untouched on error.
+func (r *DensePolyRing) TryMulPoly(a, b, c *Polynomial) error {
+	if err := verifyOperands(a, b); err != nil {
+		return err
+	}
+
+	r.mulPoly(a, b, c)
 
+	return nil
+}
+
+func (r *DensePolyRing) mulPoly(a, b, c *Polynomial) {
 	// Case 1: both inputs are already NTT with same length -> pointwise
 	if a.isNTT && b.isNTT {
 		n := len(a.inner)
-		ensureLen(c, n)
+		r.ensureLen(c, n)
 		for i := 0; i < n; i++ {
 			c.inner[i] = r.Mul(a.inner[i], b.inner[i])
 		}
@@ -191,9 +546,9 @@ func (r *DensePolyRing) MulPoly(a, b, c *Polynomial) {
 
 	newLen := len(a.inner) + len(b.inner) - 1
 
-	// Decide where to write: use c.inner if capacity is enough; else allocate.
+	// Decide where to write: use c.inner if capacity is enough and c doesn't alias an input; else allocate.
 	var out []uint64
-	if cap(c.inner) >= newLen {
+	if cap(c.inner) >= newLen && c != a && c != b {
 		out = c.inner[:newLen]
 
 		for i := range out {
@@ -217,7 +572,82 @@ func (r *DensePolyRing) MulPoly(a, b, c *Polynomial) {
 		}
 	}
 
-	// Write result into c (safe even if c==a or c==b because we used `out`).
+	// Write result into c (safe even if c==a or c==b because we used a fresh `out`).
+	c.f = a.f
+	c.inner = out
+	c.isNTT = false
+
+	r.trimTrailingZeros(c)
+}
+
+// below this many coefficients (of the shorter input) MulPolyParallel stays serial.
+const parallelMulThreshold = 64
+
+// MulPolyParallel computes c = a * b like MulPoly, but splits the outer loop of
+// the schoolbook convolution across workers goroutines. Each worker accumulates into a private
+// buffer, and the partial sums are added together at the end.
+// workers <= 0 uses runtime.GOMAXPROCS(0).
+func (r *DensePolyRing) MulPolyParallel(a, b, c *Polynomial, workers int) {
+	if err := verifyOperands(a, b); err != nil {
+		panic(err)
+	}
+
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	if a.isNTT || workers == 1 || min(len(a.inner), len(b.inner)) < parallelMulThreshold {
+		r.MulPoly(a, b, c)
+
+		return
+	}
+
+	la, lb := len(a.inner), len(b.inner)
+	workers = min(workers, la)
+	chunk := (la + workers - 1) / workers
+
+	// partials[w] holds the product of a.inner[lo:hi] and b, shifted by lo.
+	partials := make([][]uint64, workers)
+
+	wg := sync.WaitGroup{}
+	for w := 0; w < workers; w++ {
+		lo := w * chunk
+		hi := min(lo+chunk, la)
+		if lo >= hi {
+			break
+		}
+
+		wg.Add(1)
+		go func(w, lo, hi int) {
+			defer wg.Done()
+
+			part := make([]uint64, hi-lo+lb-1)
+			for i := lo; i < hi; i++ {
+				ai := a.inner[i]
+				if ai == 0 {
+					continue
+				}
+
+				for j := range b.inner {
+					part[i-lo+j] = r.Add(part[i-lo+j], r.Mul(ai, b.inner[j]))
+				}
+			}
+
+			partials[w] = part
+		}(w, lo, hi)
+	}
+
+	wg.Wait()
+
+	// not writing into c.inner directly, since c might alias a or b.
+	out := make([]uint64, la+lb-1)
+	for w, part := range partials {
+		lo := w * chunk
+		for i, v := range part {
+			out[lo+i] = r.Add(out[lo+i], v)
+		}
+	}
+
 	c.f = a.f
 	c.inner = out
 	c.isNTT = false
@@ -245,19 +675,41 @@ func (r *DensePolyRing) monomialMultPoly(ai uint64, deg int, p *Polynomial) *Pol
 // `Modern Computer Algebra` by Joachim von zur Gathen and Jürgen Gerhard
 //
 // returns q, r such that p = q*v + r.
+// Returns (nil, nil) if the inputs are invalid or b is the zero polynomial, see TryLongDiv.
 func (r *DensePolyRing) LongDiv(a, b *Polynomial) (q *Polynomial, rem *Polynomial) {
-	if !preOpVerification(a, b) {
-		return nil, nil
+	q, rem, _ = r.TryLongDiv(a, b)
+
+	return q, rem
+}
+
+var (
+	ErrDivideByZeroPolynomial = errors.New("division by the zero polynomial")
+	errInvalidDivisionInputs  = errors.New("division expects coefficient-domain polynomials over the same field")
+)
+
+// TryLongDiv is LongDiv, reporting invalid inputs as errors.
+// Dividing by the zero polynomial returns ErrDivideByZeroPolynomial.
+func (r *DensePolyRing) TryLongDiv(a, b *Polynomial) (q *Polynomial, rem *Polynomial, err error) {
+	if !preOpVerification(a, b) || b.isNTT {
+		return nil, nil, errInvalidDivisionInputs
 	}
-	fld := r.Field
 
-	if b.isNTT {
-		return nil, nil
+	if r.isZeroPoly(b) {
+		return nil, nil, ErrDivideByZeroPolynomial
 	}
 
+	fld := r.Field
+
 	n, m := a.Degree(), b.Degree()
+	if n < m {
+		// q = 0, r = a
+		rem = a.Copy()
+		r.trimTrailingZeros(rem)
 
-	u := fld.Inverse(b.LeadCoeff()) // Assumes inverse exists.
+		return makeConstantPoly(fld, 0), rem, nil
+	}
+
+	u := fld.Inverse(b.LeadCoeff()) // non-zero, since b isn't the zero polynomial.
 
 	rem = a.Copy()
 	qInner := make([]uint64, n-m+1)
@@ -281,7 +733,7 @@ func (r *DensePolyRing) LongDiv(a, b *Polynomial) (q *Polynomial, rem *Polynomia
 	q = NewPolynomial(fld, qInner, false)
 	q.removeLeadingZeroes()
 
-	return q, rem
+	return q, rem, nil
 }
 
 func makeConstantPoly(f Field, u uint64) *Polynomial {
@@ -293,6 +745,31 @@ func makeConstantPoly(f Field, u uint64) *Polynomial {
 //
 // improved from recursive function using gpt:
 func (r *DensePolyRing) PartialExtendedEuclidean(a, b *Polynomial, stopDegree int) (gcd, x, y *Polynomial) {
+	gcd, x, y, _ = r.partialExtendedEuclidean(context.Background(), a, b, stopDegree, nil)
+
+	return gcd, x, y
+}
+
+// PartialExtendedEuclideanContext is PartialExtendedEuclidean, checking ctx before each division step.
+// It returns ctx.Err() once ctx is done, thus a long run can be cancelled.
+func (r *DensePolyRing) PartialExtendedEuclideanContext(ctx context.Context, a, b *Polynomial,
+	stopDegree int) (gcd, x, y *Polynomial, err error) {
+	return r.partialExtendedEuclidean(ctx, a, b, stopDegree, nil)
+}
+
+// PartialExtendedEuclideanTraced is PartialExtendedEuclidean, calling onStep after each division step
+// (iter counts from 0) with the degrees of the current remainder and its cofactors,
+// i.e., of the values gcd, x and y would have if the algorithm stopped there (see Polynomial.Degree).
+// A nil onStep is allowed.
+func (r *DensePolyRing) PartialExtendedEuclideanTraced(a, b *Polynomial, stopDegree int,
+	onStep func(iter int, remDeg, xDeg, yDeg int)) (gcd, x, y *Polynomial) {
+	gcd, x, y, _ = r.partialExtendedEuclidean(context.Background(), a, b, stopDegree, onStep)
+
+	return gcd, x, y
+}
+
+func (r *DensePolyRing) partialExtendedEuclidean(ctx context.Context, a, b *Polynomial, stopDegree int,
+	onStep func(iter int, remDeg, xDeg, yDeg int)) (gcd, x, y *Polynomial, err error) {
 	// Work on local copies ensuring inputs aren't mutated.
 	A := a.Copy()
 	B := b.Copy()
@@ -309,14 +786,18 @@ func (r *DensePolyRing) PartialExtendedEuclidean(a, b *Polynomial, stopDegree in
 	tmp1 := &Polynomial{f: r.Field} // holds q*x1 or q*y1
 	tmp2 := &Polynomial{f: r.Field} // holds x0 - q*x1 or y0 - q*y1
 
-	for A.Degree() >= stopDegree {
+	for iter := 0; A.Degree() >= stopDegree; iter++ {
 		// If B == 0, can't divide further.
-		if B.Degree() < 0 {
+		if B.Degree() == DegreeOfZero {
 			break
 		}
 
+		if err := ctx.Err(); err != nil {
+			return nil, nil, nil, err
+		}
+
 		// A = q*B + r
-		q, rrem := r.LongDiv(A, B)
+		q, rrem := r.divide(A, B)
 		A, B = B, rrem // GCD recursive step: gcd(A, B) = gcd(B,rrem)
 
 		// following Bézout's identity:
@@ -329,20 +810,28 @@ func (r *DensePolyRing) PartialExtendedEuclidean(a, b *Polynomial, stopDegree in
 		r.MulPoly(q, y1, tmp1)    // tmp1 = q * y1
 		r.SubPoly(y0, tmp1, tmp2) // tmp2 = y0 - q*y1
 		y0, y1, tmp2 = y1, tmp2, y0
+
+		if onStep != nil {
+			onStep(iter, A.Degree(), x0.Degree(), y0.Degree())
+		}
 	}
 
 	// gcd = A, x = x0, y = y0
-	return A, x0, y0
+	return A, x0, y0, nil
 }
 
 // PolyProductMonicNegRoots computes \prod (x - r_i).
 func PolyProductMonicNegRoots(f Field, roots []uint64) *Polynomial {
-	n := len(roots)
-	if n == 0 {
-		return makeConstantPoly(f, 1)
-	}
+	coeffs := make([]uint64, len(roots)+1)
+	productMonicNegRootsInto(f, roots, coeffs)
 
-	coeffs := make([]uint64, n+1)
+	return &Polynomial{f: f, inner: coeffs, isNTT: false}
+}
+
+// productMonicNegRootsInto writes the coefficients of \prod (x - r_i) into coeffs,
+// which must be of length len(roots)+1.
+func productMonicNegRootsInto(f Field, roots []uint64, coeffs []uint64) {
+	clear(coeffs)
 	coeffs[0] = 1
 
 	deg := 0
@@ -357,13 +846,6 @@ func PolyProductMonicNegRoots(f Field, roots []uint64) *Polynomial {
 		}
 		deg++
 	}
-
-	out := make([]uint64, deg+1)
-	for i := 0; i <= deg; i++ {
-		out[i] = coeffs[i]
-	}
-
-	return &Polynomial{f: f, inner: out, isNTT: false}
 }
 
 // NTTDIV: Used GPT instead of implementing.
@@ -388,16 +870,22 @@ func (r *DensePolyRing) revTop(f *Polynomial, L int) *Polynomial {
 		return out
 	}
 
+	r.revInto(f, n, out.inner)
+	return out
+}
+
+// revInto writes the lowest len(out) coefficients of x^deg * f(1/x) into out,
+// namely out[i] = f[deg - i]. deg is taken as given, so f's leading zeros within deg are kept.
+func (r *DensePolyRing) revInto(f *Polynomial, deg int, out []uint64) {
 	// b[i] = a[n - i] if n-i >= 0
-	for i := 0; i < L; i++ {
-		j := n - i
-		if j >= 0 {
-			out.inner[i] = r.Reduce(f.inner[j])
+	for i := range out {
+		j := deg - i
+		if j >= 0 && j < len(f.inner) {
+			out[i] = r.Reduce(f.inner[j])
 		} else {
-			out.inner[i] = 0
+			out[i] = 0
 		}
 	}
-	return out
 }
 
 func nextPow2(n int) int {
@@ -435,37 +923,32 @@ func (r *DensePolyRing) mulTrunc(a, b *Polynomial, L int) *Polynomial {
 	convLen := min(L, total)
 	n := nextPow2(total)
 
-	// Prepare coeff-domain buffers of length n
-	aNTT := &Polynomial{f: r.Field, inner: make([]uint64, n), isNTT: false}
-	for i := 0; i < la; i++ {
-		aNTT.inner[i] = r.Reduce(a.inner[i])
-	}
+	// Transform into pooled length-n buffers, leaving a and b untouched.
+	aBuf, bBuf := r.getScratch(n), r.getScratch(n)
+	defer putScratch(aBuf)
+	defer putScratch(bBuf)
 
-	bNTT := &Polynomial{f: r.Field, inner: make([]uint64, n), isNTT: false}
-	for i := 0; i < lb; i++ {
-		bNTT.inner[i] = r.Reduce(b.inner[i])
-	}
-
-	// Forward NTT (these should toggle isNTT to true internally)
-	if err := r.NttForward(aNTT); err != nil {
+	if err := r.NttForwardInto(*aBuf, a.inner[:la]); err != nil {
 		panic(err)
 	}
-	if err := r.NttForward(bNTT); err != nil {
+	if err := r.NttForwardInto(*bBuf, b.inner[:lb]); err != nil {
 		panic(err)
 	}
 
-	// Pointwise multiply into aNTT
+	// Pointwise multiply into aBuf
+	aNTT, bNTT := *aBuf, *bBuf
 	for i := 0; i < n; i++ {
-		aNTT.inner[i] = r.Mul(aNTT.inner[i], bNTT.inner[i])
+		aNTT[i] = r.Mul(aNTT[i], bNTT[i])
 	}
 
-	// Inverse NTT back to coeff domain (should toggle isNTT back to false)
-	if err := r.nttBackwardNoTrim(aNTT); err != nil {
+	// Inverse NTT back to coeff domain
+	if err := r.nttBackwardInPlace(aNTT); err != nil {
 		panic(err)
 	}
 
-	// Truncate to the lowest convLen terms and return in coeff domain
-	out.inner = aNTT.inner[:convLen]
+	// Copy out the lowest convLen terms, since aBuf returns to the pool.
+	out.inner = r.makeCoeffs(convLen)
+	copy(out.inner, aNTT[:convLen])
 	return out
 }
 
@@ -524,18 +1007,24 @@ func (r *DensePolyRing) seriesInverse(b *Polynomial, k int) *Polynomial {
 // thus O(nlogn) complexity.
 // The inverse of Rev(b.Copy(),len(b)) is computed via Newton iteration in the method seriesInverse
 // with total complexity of O(nlogn).
+// Like LongDiv, returns (nil, nil) when b is the zero polynomial.
 func (r *DensePolyRing) LongDivNTT(a, b *Polynomial) (q, rem *Polynomial) {
 	if a == nil || b == nil || a.isNTT || b.isNTT {
 		panic("LongDivNTT expects non-nil coefficient-domain polynomials")
 	}
-	n := len(a.inner) - 1
-	m := len(b.inner) - 1
-	if m < 0 {
-		panic("division by zero polynomial")
+	// Use the true degrees: trailing zeros would skew the quotient length k below.
+	n := a.Degree()
+	m := b.Degree()
+	if r.isZeroPoly(b) {
+		// same as LongDiv.
+		return nil, nil
 	}
 	if n < m {
 		// q = 0, r = a
-		return &Polynomial{f: r.Field, isNTT: false, inner: []uint64{0}}, a.Copy()
+		rem = a.Copy()
+		r.trimTrailingZeros(rem)
+
+		return &Polynomial{f: r.Field, isNTT: false, inner: []uint64{0}}, rem
 	}
 
 	k := n - m + 1 // quotient length
@@ -555,8 +1044,10 @@ func (r *DensePolyRing) LongDivNTT(a, b *Polynomial) (q, rem *Polynomial) {
 	// 3) Q* = A* * T mod x^k
 	Qstar := r.mulTrunc(Astar, T, k)
 
-	// 4) q = rev_k(Q*)
-	q = r.revTop(Qstar, k) // coefficient domain
+	// 4) q = rev_k(Q*), reversed w.r.t. degree k-1 rather than Q*'s true degree,
+	// which is smaller whenever q(0) = 0.
+	q = &Polynomial{f: r.Field, isNTT: false, inner: make([]uint64, k)}
+	r.revInto(Qstar, k-1, q.inner) // coefficient domain
 
 	// 5) rem = a − q*b
 	prod := r.mulTrunc(q, b, n+1) // full product length (deg = n)
@@ -567,10 +1058,25 @@ func (r *DensePolyRing) LongDivNTT(a, b *Polynomial) (q, rem *Polynomial) {
 	return q, rem
 }
 
-const nttMulThreshold = 256 // ~coeff count where NTT starts winning
+const defaultNttMulThreshold = 256 // ~coeff count where NTT starts winning
+
+// ~quotient length where LongDivNTT starts winning, for divisors above defaultNttMulThreshold (see BenchmarkDivThreshold).
+const defaultNttDivThreshold = 96
+
+// divide computes a / b with LongDivNTT for long quotients over fields supporting its transforms, and with LongDiv
+// otherwise. Most EEA steps have quotients of degree 1, which LongDiv handles in O(len(b)).
+func (r *DensePolyRing) divide(a, b *Polynomial) (q, rem *Polynomial) {
+	n, m := a.Degree(), b.Degree()
+	if n-m+1 >= r.divThreshold && n+1 >= r.mulThreshold && r.supportsNtt(nextPow2(2*(n+1))) {
+		return r.LongDivNTT(a, b)
+	}
+
+	return r.LongDiv(a, b)
+}
 
 // mulFull computes c = a*b in coefficient domain, length len(a)+len(b)-1.
-// It uses mulTrunc with L = total when big enough; otherwise falls back to Mul.
+// It uses mulTrunc with L = total when big enough and the field supports an NTT of that size;
+// otherwise falls back to Mul.
 func (r *DensePolyRing) mulFull(a, b, c *Polynomial) {
 	la, lb := len(a.inner), len(b.inner)
 	if la == 0 || lb == 0 {
@@ -578,11 +1084,11 @@ func (r *DensePolyRing) mulFull(a, b, c *Polynomial) {
 		return
 	}
 	total := la + lb - 1
-	if total >= nttMulThreshold {
+	if total >= r.mulThreshold && r.supportsNtt(nextPow2(total)) {
 		prod := r.mulTrunc(a, b, total) // NTT under the hood, coeff-domain out
 		// write into c without extra allocs when possible
 		if cap(c.inner) < total {
-			c.inner = make([]uint64, total)
+			c.inner = r.makeCoeffs(total)
 		} else {
 			c.inner = c.inner[:total]
 		}
@@ -595,6 +1101,20 @@ func (r *DensePolyRing) mulFull(a, b, c *Polynomial) {
 }
 
 func (r *DensePolyRing) NttPartialExtendedEuclidean(a, b *Polynomial, stopDegree int) (gcd, x, y *Polynomial) {
+	gcd, x, y, _ = r.nttPartialExtendedEuclidean(context.Background(), a, b, stopDegree)
+
+	return gcd, x, y
+}
+
+// NttPartialExtendedEuclideanContext is NttPartialExtendedEuclidean, checking ctx before each division step,
+// see PartialExtendedEuclideanContext.
+func (r *DensePolyRing) NttPartialExtendedEuclideanContext(ctx context.Context, a, b *Polynomial,
+	stopDegree int) (gcd, x, y *Polynomial, err error) {
+	return r.nttPartialExtendedEuclidean(ctx, a, b, stopDegree)
+}
+
+func (r *DensePolyRing) nttPartialExtendedEuclidean(ctx context.Context, a, b *Polynomial,
+	stopDegree int) (gcd, x, y *Polynomial, err error) {
 	// Work on local copies ensuring inputs aren't mutated (coeff domain expected).
 	A := a.Copy()
 	B := b.Copy()
@@ -614,17 +1134,16 @@ func (r *DensePolyRing) NttPartialExtendedEuclidean(a, b *Polynomial, stopDegree
 
 	for A.Degree() >= stopDegree {
 		// If B == 0, can't divide further.
-		if B.Degree() < 0 || len(B.inner) == 0 {
+		if B.Degree() == DegreeOfZero {
 			break
 		}
 
-		// A = q*B + r  (use NTT-accelerated division when large)
-		var q, rrem *Polynomial
-		if len(A.inner)+len(B.inner) >= nttMulThreshold { // simple heuristic
-			q, rrem = r.LongDivNTT(A, B)
-		} else {
-			q, rrem = r.LongDiv(A, B)
+		if err := ctx.Err(); err != nil {
+			return nil, nil, nil, err
 		}
+
+		// A = q*B + r  (use NTT-accelerated division for long quotients)
+		q, rrem := r.divide(A, B)
 		A, B = B, rrem // gcd(A,B) = gcd(B,rrem)
 
 		// x update: (x0, x1) = (x1, x0 - q*x1)
@@ -639,5 +1158,5 @@ func (r *DensePolyRing) NttPartialExtendedEuclidean(a, b *Polynomial, stopDegree
 	}
 
 	// gcd = A, x = x0, y = y0
-	return A, x0, y0
+	return A, x0, y0, nil
 }
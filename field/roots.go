@@ -0,0 +1,181 @@
+package field
+
+import (
+	"slices"
+)
+
+// Sqrt returns a square root of a and true, or false if a is not a square in the field.
+func (f *PrimeField) Sqrt(a uint64) (uint64, bool) {
+	return sqrt(f, a)
+}
+
+// sqrt uses Tonelli-Shanks: write p-1 = q*2^s with q odd, start from a^((q+1)/2),
+// and fix it using powers of a non-residue (the generator) of order 2^s.
+func sqrt(f Field, a uint64) (uint64, bool) {
+	a = f.Reduce(a)
+	if a == 0 {
+		return 0, true
+	}
+
+	p := f.Modulus()
+	if f.Pow(a, (p-1)/2) != 1 { // Euler's criterion.
+		return 0, false
+	}
+
+	q, s := p-1, 0
+	for q%2 == 0 {
+		q /= 2
+		s++
+	}
+
+	c := f.Pow(f.Generator(), q)
+	t := f.Pow(a, q)
+	res := f.Pow(a, (q+1)/2)
+
+	// invariant: res^2 = a*t, where t has order 2^i < 2^m.
+	for m := s; t != 1; {
+		i := 0
+		for t2 := t; t2 != 1; t2 = f.Mul(t2, t2) {
+			i++
+		}
+
+		b := c
+		for j := 0; j < m-i-1; j++ {
+			b = f.Mul(b, b)
+		}
+
+		m = i
+		c = f.Mul(b, b)
+		t = f.Mul(t, c)
+		res = f.Mul(res, b)
+	}
+
+	return res, true
+}
+
+// Roots returns the distinct roots of a in the field, in ascending order, or an empty slice if there are none.
+// Degrees 1 and 2 use closed-form formulas. Higher degrees keep the part of a that splits into linear factors,
+// gcd(a, x^(p-1) - 1), and split it with Cantor-Zassenhaus. Panics on the zero polynomial or NTT inputs.
+func (r *DensePolyRing) Roots(a *Polynomial) []uint64 {
+	monic, _ := r.Monic(a)
+
+	roots := []uint64{}
+
+	// 0 is a root iff x divides a; factor x out.
+	lowest := 0
+	for r.Equals(monic.inner[lowest], 0) {
+		lowest++
+	}
+
+	if lowest > 0 {
+		roots = append(roots, 0)
+		monic = NewPolynomial(r.Field, monic.inner[lowest:], false)
+	}
+
+	switch monic.Degree() {
+	case 0:
+	case 1, 2:
+		roots = r.appendSmallDegreeRoots(roots, monic)
+	default:
+		// x^(p-1) - 1 = \prod_{c != 0} (x - c).
+		h := r.powMod(NewPolynomial(r.Field, []uint64{0, 1}, false), r.Modulus()-1, monic)
+		r.SubPoly(h, makeConstantPoly(r.Field, 1), h)
+
+		roots = r.appendSplitRoots(roots, r.gcd(monic, h))
+	}
+
+	slices.Sort(roots)
+
+	return slices.Compact(roots)
+}
+
+// appendSmallDegreeRoots appends the roots of a monic polynomial of degree 1 or 2.
+func (r *DensePolyRing) appendSmallDegreeRoots(roots []uint64, monic *Polynomial) []uint64 {
+	c0 := r.Reduce(monic.inner[0])
+	if monic.Degree() == 1 {
+		return append(roots, r.Neg(c0))
+	}
+
+	// x^2 + bx + c = 0  <=>  x = (-b +- sqrt(b^2 - 4c)) / 2.
+	b := r.Reduce(monic.inner[1])
+	disc := r.Sub(r.Mul(b, b), r.Mul(4%r.Modulus(), c0))
+
+	s, ok := sqrt(r.Field, disc)
+	if !ok {
+		return roots
+	}
+
+	halfInv := r.Inverse(2)
+
+	return append(roots,
+		r.Mul(r.Sub(s, b), halfInv),
+		r.Mul(r.Sub(r.Neg(s), b), halfInv),
+	)
+}
+
+// appendSplitRoots appends the roots of a monic g that is a product of distinct linear factors (x - c), c != 0.
+func (r *DensePolyRing) appendSplitRoots(roots []uint64, g *Polynomial) []uint64 {
+	if g.Degree() <= 0 {
+		return roots
+	}
+
+	if g.Degree() <= 2 {
+		return r.appendSmallDegreeRoots(roots, g)
+	}
+
+	// (x + delta)^((p-1)/2) is 1 on the roots c where c + delta is a non-zero square, and -1 or 0 on the others.
+	// For distinct roots, some delta tells two roots apart, thus it splits g.
+	one := makeConstantPoly(r.Field, 1)
+	for delta := uint64(1); ; delta++ {
+		h := r.powMod(NewPolynomial(r.Field, []uint64{r.Reduce(delta), 1}, false), (r.Modulus()-1)/2, g)
+		r.SubPoly(h, one, h)
+
+		if r.isZeroPoly(h) {
+			continue
+		}
+
+		d := r.gcd(g, h)
+		if d.Degree() <= 0 || d.Degree() == g.Degree() {
+			continue
+		}
+
+		q, _ := r.LongDiv(g, d)
+		roots = r.appendSplitRoots(roots, d)
+
+		return r.appendSplitRoots(roots, q)
+	}
+}
+
+// powMod returns base^e mod m.
+func (r *DensePolyRing) powMod(base *Polynomial, e uint64, m *Polynomial) *Polynomial {
+	_, b := r.LongDiv(base, m)
+	res := makeConstantPoly(r.Field, 1)
+
+	for ; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			r.MulPoly(res, b, res)
+			_, res = r.LongDiv(res, m)
+		}
+
+		r.MulPoly(b, b, b)
+		_, b = r.LongDiv(b, m)
+	}
+
+	return res
+}
+
+// gcd returns the monic gcd of a and b, or the zero polynomial if both are zero.
+func (r *DensePolyRing) gcd(a, b *Polynomial) *Polynomial {
+	for !r.isZeroPoly(b) {
+		_, rem := r.LongDiv(a, b)
+		a, b = b, rem
+	}
+
+	if r.isZeroPoly(a) {
+		return makeConstantPoly(r.Field, 0)
+	}
+
+	monic, _ := r.Monic(a)
+
+	return monic
+}
@@ -0,0 +1,78 @@
+package field
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSqrt(t *testing.T) {
+	a := assert.New(t)
+
+	// 65537 - 1 = 2^16, the worst case of Tonelli-Shanks. 7 and 11 are 3 mod 4.
+	for _, prime := range []uint64{7, 11, 157, 65537, 9191248642791733759} {
+		fld, err := NewPrimeField(prime)
+		a.NoError(err)
+
+		f := fld.(*PrimeField)
+		rnd := rand.New(rand.NewSource(int64(prime)))
+
+		for i := 0; i < 200; i++ {
+			x := f.Reduce(rnd.Uint64())
+
+			s, ok := f.Sqrt(f.Mul(x, x))
+			a.True(ok)
+			a.True(s == x || s == f.Neg(x), "p=%d x=%d s=%d", prime, x, s)
+
+			// the generator is not a square.
+			_, ok = f.Sqrt(f.Mul(f.Mul(x, x), f.Generator()))
+			a.Equal(x == 0, ok)
+		}
+	}
+}
+
+func TestRoots(t *testing.T) {
+	a := assert.New(t)
+
+	for _, prime := range []uint64{157, 65537, 9191248642791733759} {
+		f, err := NewPrimeField(prime)
+		a.NoError(err)
+
+		pr := NewDensePolyRing(f)
+		rnd := rand.New(rand.NewSource(int64(prime)))
+
+		// from known roots, with repetitions and an irreducible quadratic factor.
+		irreducible := NewPolynomial(f, []uint64{f.Neg(f.Generator()), 0, 1}, false) // x^2 - g.
+
+		for _, numRoots := range []int{1, 2, 3, 5, 12} {
+			roots := make([]uint64, numRoots)
+			for i := range roots {
+				roots[i] = rnd.Uint64() % prime
+			}
+
+			roots[0] = roots[numRoots-1] // repeated root.
+			if numRoots > 3 {
+				roots[1] = 0
+			}
+
+			p := PolyProductMonicNegRoots(f, roots)
+			pr.MulScalar(p, 5, p)
+
+			expected := slices.Clone(roots)
+			slices.Sort(expected)
+			expected = slices.Compact(expected)
+
+			a.Equal(expected, pr.Roots(p), "p=%d roots=%v", prime, roots)
+
+			withIrreducible := &Polynomial{}
+			pr.MulPoly(p, irreducible, withIrreducible)
+			a.Equal(expected, pr.Roots(withIrreducible), "p=%d roots=%v", prime, roots)
+		}
+
+		a.Empty(pr.Roots(irreducible))
+		a.Empty(pr.Roots(makeConstantPoly(f, 3)))
+		a.Equal([]uint64{f.Neg(3)}, pr.Roots(NewPolynomial(f, []uint64{6, 2, 0}, false))) // 2x + 6.
+	}
+}
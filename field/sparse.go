@@ -0,0 +1,191 @@
+package field
+
+import (
+	"sort"
+)
+
+// SparseTerm is the monomial Coeff * x^Degree.
+type SparseTerm struct {
+	Degree int
+	Coeff  uint64
+}
+
+/*
+SparsePolynomial holds only the non-zero terms of a polynomial, sorted by ascending degree.
+Useful for high-degree polynomials with few terms, e.g., the locator x^n - 1, where
+a dense Polynomial would materialize n+1 coefficients.
+*/
+type SparsePolynomial struct {
+	f     Field
+	terms []SparseTerm
+}
+
+// NewSparsePolynomial normalizes terms: coefficients are reduced, terms of the same degree are summed,
+// and zero terms are dropped. terms is not modified.
+func NewSparsePolynomial(f Field, terms []SparseTerm) *SparsePolynomial {
+	sorted := make([]SparseTerm, len(terms))
+	copy(sorted, terms)
+
+	for _, t := range sorted {
+		if t.Degree < 0 {
+			panic("negative degree in sparse polynomial")
+		}
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Degree < sorted[j].Degree })
+
+	normalized := make([]SparseTerm, 0, len(sorted))
+	for _, t := range sorted {
+		c := f.Reduce(t.Coeff)
+
+		if last := len(normalized) - 1; last >= 0 && normalized[last].Degree == t.Degree {
+			normalized[last].Coeff = f.Add(normalized[last].Coeff, c)
+			continue
+		}
+
+		normalized = append(normalized, SparseTerm{Degree: t.Degree, Coeff: c})
+	}
+
+	out := normalized[:0]
+	for _, t := range normalized {
+		if t.Coeff != 0 {
+			out = append(out, t)
+		}
+	}
+
+	return &SparsePolynomial{f: f, terms: out}
+}
+
+// FromDense returns the sparse form of a coefficient-domain polynomial.
+func FromDense(p *Polynomial) *SparsePolynomial {
+	if p.isNTT {
+		panic("FromDense not supported in NTT domain")
+	}
+
+	terms := []SparseTerm{}
+	for i, c := range p.inner {
+		if c = p.f.Reduce(c); c != 0 {
+			terms = append(terms, SparseTerm{Degree: i, Coeff: c})
+		}
+	}
+
+	return &SparsePolynomial{f: p.f, terms: terms}
+}
+
+// ToDense materializes s as a coefficient-domain Polynomial of length Degree()+1.
+func (s *SparsePolynomial) ToDense() *Polynomial {
+	if s.IsZero() {
+		return makeConstantPoly(s.f, 0)
+	}
+
+	inner := make([]uint64, s.Degree()+1)
+	for _, t := range s.terms {
+		inner[t.Degree] = t.Coeff
+	}
+
+	return NewPolynomial(s.f, inner, false)
+}
+
+func (s *SparsePolynomial) IsZero() bool {
+	return len(s.terms) == 0
+}
+
+// Degree returns the highest degree of a non-zero term, or DegreeOfZero for the zero polynomial.
+func (s *SparsePolynomial) Degree() int {
+	if s.IsZero() {
+		return DegreeOfZero
+	}
+
+	return s.terms[len(s.terms)-1].Degree
+}
+
+// Terms returns a copy of the non-zero terms, sorted by ascending degree.
+func (s *SparsePolynomial) Terms() []SparseTerm {
+	terms := make([]SparseTerm, len(s.terms))
+	copy(terms, s.terms)
+
+	return terms
+}
+
+// Evaluate computes s(x) in O(t log(deg)) for t terms.
+func (s *SparsePolynomial) Evaluate(x uint64) uint64 {
+	f := s.f
+	x = f.Reduce(x)
+
+	result := uint64(0)
+	xPow, prevDeg := uint64(1), 0
+
+	for _, t := range s.terms {
+		// x^deg_i = x^deg_{i-1} * x^(deg_i - deg_{i-1})
+		xPow = f.Mul(xPow, f.Pow(x, uint64(t.Degree-prevDeg)))
+		prevDeg = t.Degree
+
+		result = f.Add(result, f.Mul(t.Coeff, xPow))
+	}
+
+	return result
+}
+
+// Derivative returns the formal derivative of s, see DensePolyRing.Derivative.
+func (s *SparsePolynomial) Derivative() *SparsePolynomial {
+	terms := make([]SparseTerm, 0, len(s.terms))
+	for _, t := range s.terms {
+		if t.Degree > 0 {
+			terms = append(terms, SparseTerm{Degree: t.Degree - 1, Coeff: mulInt(s.f, t.Coeff, uint64(t.Degree))})
+		}
+	}
+
+	return NewSparsePolynomial(s.f, terms)
+}
+
+// Add returns s + q.
+func (s *SparsePolynomial) Add(q *SparsePolynomial) *SparsePolynomial {
+	f := s.f
+	terms := make([]SparseTerm, 0, len(s.terms)+len(q.terms))
+
+	// merge two sorted term lists.
+	i, j := 0, 0
+	for i < len(s.terms) || j < len(q.terms) {
+		switch {
+		case j == len(q.terms) || (i < len(s.terms) && s.terms[i].Degree < q.terms[j].Degree):
+			terms = append(terms, s.terms[i])
+			i++
+		case i == len(s.terms) || q.terms[j].Degree < s.terms[i].Degree:
+			terms = append(terms, q.terms[j])
+			j++
+		default:
+			if c := f.Add(s.terms[i].Coeff, q.terms[j].Coeff); c != 0 {
+				terms = append(terms, SparseTerm{Degree: s.terms[i].Degree, Coeff: c})
+			}
+			i++
+			j++
+		}
+	}
+
+	return &SparsePolynomial{f: f, terms: terms}
+}
+
+// MulDense returns the dense product s * p in O(t * len(p)) for t terms.
+func (s *SparsePolynomial) MulDense(p *Polynomial) *Polynomial {
+	if p.isNTT {
+		panic("MulDense not supported in NTT domain")
+	}
+
+	if s.IsZero() || p.IsZero() {
+		return makeConstantPoly(s.f, 0)
+	}
+
+	f := s.f
+	out := make([]uint64, s.Degree()+len(p.inner))
+
+	for _, t := range s.terms {
+		for i, c := range p.inner {
+			out[t.Degree+i] = f.Add(out[t.Degree+i], f.Mul(t.Coeff, f.Reduce(c)))
+		}
+	}
+
+	prod := NewPolynomial(f, out, false)
+	prod.removeLeadingZeroes()
+
+	return prod
+}
@@ -0,0 +1,79 @@
+package field
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSparsePolynomial(t *testing.T) {
+	a := assert.New(t)
+
+	f, err := NewPrimeField(65537)
+	a.NoError(err)
+
+	pr := NewDensePolyRing(f)
+
+	t.Run("locator", func(t *testing.T) {
+		// x^n - 1
+		n := 1 << 12
+		s := NewSparsePolynomial(f, []SparseTerm{{Degree: n, Coeff: 1}, {Degree: 0, Coeff: f.Neg(1)}})
+		dense := s.ToDense()
+
+		a.Equal(n, s.Degree())
+		a.Equal(n, dense.Degree())
+
+		for _, x := range []uint64{0, 1, 2, 3, 12345, 65536} {
+			a.Equal(pr.Evaluate(dense, x), s.Evaluate(x))
+		}
+
+		root, err := f.GetRootOfUnity(uint64(n))
+		a.NoError(err)
+		a.Equal(uint64(0), s.Evaluate(root))
+	})
+
+	t.Run("normalization", func(t *testing.T) {
+		s := NewSparsePolynomial(f, []SparseTerm{{3, 1}, {1, 65538}, {3, 65536}, {5, 0}})
+		a.Equal([]SparseTerm{{1, 1}}, s.Terms())
+
+		a.True(NewSparsePolynomial(f, nil).IsZero())
+		a.True(NewSparsePolynomial(f, nil).ToDense().IsZero())
+	})
+
+	t.Run("dense round trip", func(t *testing.T) {
+		p := NewPolynomial(f, []uint64{0, 5, 0, 0, 7, 0}, false)
+		s := FromDense(p)
+
+		a.Equal([]SparseTerm{{1, 5}, {4, 7}}, s.Terms())
+		a.True(p.Equals(s.ToDense()))
+
+		for _, x := range []uint64{0, 1, 2, 3, 12345} {
+			a.Equal(pr.Evaluate(p, x), s.Evaluate(x))
+		}
+	})
+
+	t.Run("add", func(t *testing.T) {
+		p := randomPolynomial(f, 12345, 20)
+		q := randomPolynomial(f, 54321, 30)
+
+		expected := &Polynomial{}
+		pr.AddPoly(p, q, expected)
+
+		a.True(expected.Equals(FromDense(p).Add(FromDense(q)).ToDense()))
+
+		// cancellation.
+		neg := &Polynomial{}
+		pr.MulScalar(p, f.Neg(1), neg)
+		a.True(FromDense(p).Add(FromDense(neg)).IsZero())
+	})
+
+	t.Run("mul dense", func(t *testing.T) {
+		s := NewSparsePolynomial(f, []SparseTerm{{0, 3}, {7, 2}, {40, 1}})
+		p := randomPolynomial(f, 12345, 20)
+
+		expected := &Polynomial{}
+		pr.MulPoly(s.ToDense(), p, expected)
+
+		a.True(expected.Equals(s.MulDense(p)))
+	})
+}
@@ -1,7 +1,13 @@
 package gao
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/jonathanmweiss/go-gao/field"
 )
@@ -22,11 +28,13 @@ type Coder interface {
 type Decoder interface {
 	Coder
 	Decode(encodedData map[uint64]uint64) ([]uint64, error)
+	DecodeOrdered(codeword []uint64, present []bool) ([]uint64, error)
 }
 
 type Encoder interface {
 	Coder
 	Encode(data []uint64) (map[uint64]uint64, error)
+	EncodeOrdered(data []uint64) ([]uint64, error)
 }
 
 type CodeParams struct {
@@ -40,11 +48,56 @@ type Code struct {
 	CodeParams
 	pr           field.PolyRing
 	interpolator *field.Interpolator
-	// g0 polynomial from the Gao code.
+	// g0 polynomial from the Gao code, computed on first use, see locator.
 	// with fast EvaluationMaps like NTT, this polynomial can be used to do fast division.
-	g0 *field.Polynomial
+	g0     *field.Polynomial
+	g0Once sync.Once
+	// set instead of g0 when the EvaluationMap describes its locator sparsely (e.g., x^n - 1 for NTT).
+	g0Sparse *field.SparsePolynomial
 
 	stopDegree int
+
+	// telemetry of the last decode, see LastDecodePath.
+	lastPath   atomic.Int32
+	lastErrors atomic.Int64
+
+	tracer Tracer
+
+	// data index i -> x_j^i for the evaluation points x_j, see UpdateSymbol.
+	basis sync.Map
+
+	// evaluates g0', see LocatorDerivativeEval.
+	g0Deriv     func(x uint64) uint64
+	g0DerivOnce sync.Once
+
+	// the column multipliers of the dual code, see Syndromes.
+	dualWeights     []uint64
+	dualWeightsOnce sync.Once
+
+	// interpolates over the evaluation points of non-NTT maps, see domainInterpolator.
+	domainInterp     *field.DomainInterpolator
+	domainInterpErr  error
+	domainInterpOnce sync.Once
+}
+
+// decodePath is the branch taken by a decode.
+type decodePath int32
+
+const (
+	noDecodePath decodePath = iota
+	genericDecodePath
+	nttDecodePath
+)
+
+func (p decodePath) String() string {
+	switch p {
+	case genericDecodePath:
+		return "generic"
+	case nttDecodePath:
+		return "ntt"
+	default:
+		return ""
+	}
 }
 
 func (c *CodeParams) N() int {
@@ -59,13 +112,42 @@ func (c *CodeParams) MaxErrors() int {
 	return c.maxErrors
 }
 
+// nttDomain reports whether the evaluation points are a full subgroup of roots of unity (or a coset of it),
+// which fast Gao requires. NTT evaluation maps of other lengths evaluate over a prefix, and decode generically.
+func (c *CodeParams) nttDomain() bool {
+	return c.EvaluationMap.isNTT() && field.IsPowerOfTwo(uint64(c.n))
+}
+
 var ErrNSmallerThanK = errors.New("redundancy value `n` must be greater than or equal to data size `k`")
+var ErrDomainTooSmall = errors.New("field is too small for `n` distinct non-zero evaluation points")
+var ErrNoRootOfUnity = errors.New("field has no root of unity of order nextPow2(`n`)")
 
+// NewCodeParameters returns ErrDomainTooSmall unless the field has n distinct non-zero points, i.e., n < p,
+// and, for NTT evaluation maps, ErrNoRootOfUnity, wrapping the field's error, unless it has a root of unity
+// of order nextPow2(n).
 func NewCodeParameters(e EvaluationMap, n, k int) (CodeParams, error) {
 	if n < k {
 		return CodeParams{}, ErrNSmallerThanK
 	}
 
+	// compared as uint64, as the modulus of a 63-bit prime field may not fit an int on 32-bit platforms.
+	f := e.PrimeField()
+	if uint64(n) >= f.Modulus() {
+		return CodeParams{}, ErrDomainTooSmall
+	}
+
+	if e.isNTT() {
+		if err := validateNttDomain(f, n); err != nil {
+			return CodeParams{}, err
+		}
+	}
+
+	if dv, ok := e.(domainValidator); ok {
+		if err := dv.validateDomain(n); err != nil {
+			return CodeParams{}, err
+		}
+	}
+
 	return CodeParams{
 		EvaluationMap: e,
 		n:             n,
@@ -74,46 +156,289 @@ func NewCodeParameters(e EvaluationMap, n, k int) (CodeParams, error) {
 	}, nil
 }
 
+// sparseLocatorMap is implemented by EvaluationMaps whose locator polynomial has few terms.
+type sparseLocatorMap interface {
+	// returns nil if the locator isn't sparse.
+	sparseLocatorPolynomial(n int) *field.SparsePolynomial
+}
+
+// interpolatingMap is implemented by NTT EvaluationMaps whose interpolation isn't a plain NttBackward,
+// e.g., over a coset of the roots of unity.
+type interpolatingMap interface {
+	// returns the polynomial whose evaluations over EvaluationPoints(len(ys)) are ys, transforming ys in place.
+	interpolate(ys []uint64) (*field.Polynomial, error)
+}
+
+// domainValidator is implemented by EvaluationMaps with constraints on n beyond the ones NewCodeParameters checks,
+// e.g., PuncturedEvaluator, whose base must have the dropped points.
+type domainValidator interface {
+	validateDomain(n int) error
+}
+
+// polyRingMap is implemented by EvaluationMaps that own a PolyRing, which the Code then shares
+// (along with its twiddle cache) instead of creating its own.
+type polyRingMap interface {
+	polyRing() field.PolyRing
+}
+
+// NewCodeGao doesn't compute the locator polynomial g0(x) = (x - x_1)(x - x_2)...(x - x_n),
+// which takes O(n^2) with SlowEvaluators; the first decode does.
 func NewCodeGao(c CodeParams) *Code {
-	fld := c.EvaluationMap.PrimeField()
-	pr := field.NewDensePolyRing(fld)
-	// create g0(x) = (x - x_1)(x - x_2)...(x - x_n)
-	// TODO: for FastEvaluationMaps, we can skip this step, and create g0 without computing it.
+	var pr field.PolyRing
+	if rm, ok := c.EvaluationMap.(polyRingMap); ok {
+		pr = rm.polyRing()
+	} else {
+		pr = field.NewDensePolyRing(c.EvaluationMap.PrimeField())
+	}
 
-	return &Code{
+	code := &Code{
 		CodeParams:   c,
 		pr:           pr,
-		g0:           c.EvaluationMap.GenerateLocatorPolynomial(c.N()),
 		interpolator: field.NewInterpolator(pr),
 		stopDegree:   (c.N() + c.K()) / 2,
 	}
+	code.lastErrors.Store(-1)
+
+	if sl, ok := c.EvaluationMap.(sparseLocatorMap); ok {
+		code.g0Sparse = sl.sparseLocatorPolynomial(c.N())
+	}
+
+	return code
 }
 
 func (gao *Code) Copy() *Code {
-	return &Code{
+	cpy := &Code{
 		CodeParams:   gao.CodeParams,
-		g0:           gao.g0.Copy(),
-		interpolator: field.NewInterpolator(gao.pr),
+		pr:           gao.pr,
+		interpolator: gao.interpolator, // stateless.
 		stopDegree:   gao.stopDegree,
+		g0Sparse:     gao.g0Sparse, // immutable.
+	}
+	cpy.lastErrors.Store(-1)
+
+	// compute a dense g0 once for both codes.
+	if gao.g0Sparse == nil {
+		g0 := gao.locator().Copy()
+		cpy.g0Once.Do(func() { cpy.g0 = g0 })
+	}
+
+	// likewise the interpolation denominators, which are immutable.
+	if !gao.nttDomain() {
+		interp, err := gao.domainInterpolator()
+		cpy.domainInterpOnce.Do(func() { cpy.domainInterp, cpy.domainInterpErr = interp, err })
+	}
+
+	return cpy
+}
+
+// locator returns g0 in dense form. With a sparse g0 the dense form is rebuilt on each call in O(n),
+// which is negligible next to decoding.
+func (gao *Code) locator() *field.Polynomial {
+	if gao.g0Sparse != nil {
+		return gao.g0Sparse.ToDense()
+	}
+
+	gao.g0Once.Do(func() {
+		gao.g0 = gao.EvaluationMap.GenerateLocatorPolynomial(gao.N())
+	})
+
+	return gao.g0
+}
+
+var ErrInvalidStopDegree = errors.New("stop degree must be between (n+k)/2 and n")
+
+// SetStopDegree sets the degree at which the partial EEA of the decoder stops, by default (n+k)/2.
+// The decoder corrects e errors when k+e <= d and e <= n-d, hence raising d towards n reduces
+// the correction radius (and MaxErrors) to min(d-k, n-d); d = n only detects errors.
+// In exchange, the EEA stops sooner: the degrees of its quotients sum up to at most n-d,
+// which mostly speeds up rejecting words with many errors. Lowering d below (n+k)/2 corrects less
+// with more iterations, thus it is rejected.
+// Not safe to call concurrently with decoding.
+func (gao *Code) SetStopDegree(d int) error {
+	if d < (gao.N()+gao.K())/2 || d > gao.N() {
+		return ErrInvalidStopDegree
 	}
+
+	gao.stopDegree = d
+	gao.maxErrors = min(d-gao.K(), gao.N()-d)
+
+	return nil
 }
 
 var ErrDataTooLarge = errors.New("data too large")
 var ErrDataElementsTooLarge = errors.New("data elements too large")
 
 func (gao *Code) Encode(data []uint64) (map[uint64]uint64, error) {
-	f := gao.PrimeField()
+	ys, err := gao.EncodeOrdered(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return toPointsMap(gao.EvaluationMap.EvaluationPoints(gao.N()), ys), nil
+}
+
+// toPointsMap creates the map x_i -> y_i.
+func toPointsMap(xs, ys []uint64) map[uint64]uint64 {
+	points := make(map[uint64]uint64, len(xs))
+
+	for i, y := range ys {
+		points[xs[i]] = y
+	}
+
+	return points
+}
 
+// validateData checks data can be encoded by a code of data size k over the field f.
+func validateData(f field.Field, data []uint64, k int) error {
 	q := f.Modulus()
-	for _, d := range data {
+	for i, d := range data {
 		if d >= q {
-			return nil, ErrDataElementsTooLarge
+			return &SymbolOverflowError{Index: i, Value: d, Modulus: q}
 		}
 	}
 
 	// check data length.
-	if len(data) > gao.K() {
-		return nil, ErrDataTooLarge
+	if len(data) > k {
+		return ErrDataTooLarge
+	}
+
+	return nil
+}
+
+var ErrSymbolIndexOutOfRange = errors.New("symbol index must be between 0 and `k`-1")
+
+// UpdateSymbol updates codeword in place after data[index] changed from oldVal to newVal, in O(n) instead
+// of re-encoding. Encoding is linear, thus each position f(x_j) changes by (newVal - oldVal) * x_j^index.
+// Points missing from codeword (e.g., erasures) are left missing. The powers x_j^index are computed once
+// per index and cached.
+func (gao *Code) UpdateSymbol(codeword map[uint64]uint64, index int, oldVal, newVal uint64) error {
+	if index < 0 || index >= gao.K() {
+		return ErrSymbolIndexOutOfRange
+	}
+
+	fld := gao.PrimeField()
+	for _, v := range []uint64{oldVal, newVal} {
+		if v >= fld.Modulus() {
+			return &SymbolOverflowError{Index: index, Value: v, Modulus: fld.Modulus()}
+		}
+	}
+
+	delta := fld.Sub(newVal, oldVal)
+	if delta == 0 {
+		return nil
+	}
+
+	xs := gao.EvaluationMap.EvaluationPoints(gao.N())
+	powers := gao.basisPowers(xs, index)
+
+	for j, x := range xs {
+		if y, ok := codeword[x]; ok {
+			codeword[x] = fld.Add(fld.Reduce(y), fld.Mul(delta, powers[j]))
+		}
+	}
+
+	return nil
+}
+
+// basisPowers returns x_j^index for the evaluation points xs.
+func (gao *Code) basisPowers(xs []uint64, index int) []uint64 {
+	if powers, ok := gao.basis.Load(index); ok {
+		return powers.([]uint64)
+	}
+
+	fld := gao.PrimeField()
+	powers := make([]uint64, len(xs))
+	for j, x := range xs {
+		powers[j] = fld.Pow(x, uint64(index))
+	}
+
+	actual, _ := gao.basis.LoadOrStore(index, powers)
+
+	return actual.([]uint64)
+}
+
+// GeneratorMatrix returns the k×n generator matrix G of the code, whose i'th row is the encoding of the
+// unit message e_i, namely x^i over EvaluationPoints(n), thus EncodeOrdered(data) = data · G.
+// The rows are shared with UpdateSymbol's cache, hence returned as copies the caller owns.
+func (gao *Code) GeneratorMatrix() [][]uint64 {
+	xs := gao.EvaluationMap.EvaluationPoints(gao.N())
+
+	g := make([][]uint64, gao.K())
+	for i := range g {
+		g[i] = slices.Clone(gao.basisPowers(xs, i))
+	}
+
+	return g
+}
+
+var ErrDataLengthMismatch = errors.New("data length must equal data size `k`")
+
+var ErrInvalidMessageLength = errors.New("message length `k` must be between 1 and `n`")
+
+// EncodeVariable encodes data as a message of length k, for any 1 <= k <= n, over the code's evaluation points,
+// so a single Code serves several rates. len(data) must be at most k. Decode with DecodeVariable and the same k.
+func (gao *Code) EncodeVariable(data []uint64, k int) (map[uint64]uint64, error) {
+	rate, err := gao.withMessageLength(k)
+	if err != nil {
+		return nil, err
+	}
+
+	return rate.Encode(data)
+}
+
+// DecodeVariable decodes received, encoded by EncodeVariable with message length k.
+// It corrects up to (n-k)/2 errors: the partial EEA stops at (n+k)/2, regardless of SetStopDegree.
+func (gao *Code) DecodeVariable(received map[uint64]uint64, k int) ([]uint64, error) {
+	rate, err := gao.withMessageLength(k)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := rate.Decode(received)
+
+	gao.lastPath.Store(rate.lastPath.Load())
+	gao.lastErrors.Store(rate.lastErrors.Load())
+
+	return msg, err
+}
+
+// withMessageLength returns a copy of gao for messages of length k, sharing its ring and locator.
+func (gao *Code) withMessageLength(k int) (*Code, error) {
+	if k < 1 || k > gao.N() {
+		return nil, ErrInvalidMessageLength
+	}
+
+	rate := gao.Copy()
+	rate.tracer = gao.tracer
+	rate.k = k
+	rate.maxErrors = (gao.N() - k) / 2
+	rate.stopDegree = (gao.N() + k) / 2
+
+	return rate, nil
+}
+
+// EncodeExact is a strict EncodeOrdered that rejects data whose length isn't exactly k.
+//
+// Encode and EncodeOrdered are lenient: data shorter than k is zero-padded. Note the padding is in
+// the coefficients of the message polynomial f (the highest ones are zero), not in codeword positions;
+// every codeword position f(x_i) depends on all coefficients. Thus, the decoder's deg(f) < k check
+// is unaffected, but it can't tell a short message from one ending with zeros: Decode trims trailing zeros.
+func (gao *Code) EncodeExact(data []uint64) ([]uint64, error) {
+	if len(data) != gao.K() {
+		return nil, ErrDataLengthMismatch
+	}
+
+	return gao.EncodeOrdered(data)
+}
+
+// EncodeOrdered returns the codeword as a slice of length n, where the i'th
+// element is the evaluation at EvaluationPoints(n)[i].
+// data shorter than k is zero-padded, see EncodeExact.
+func (gao *Code) EncodeOrdered(data []uint64) ([]uint64, error) {
+	f := gao.PrimeField()
+
+	if err := validateData(f, data, gao.K()); err != nil {
+		return nil, err
 	}
 
 	// pad:
@@ -122,107 +447,1014 @@ func (gao *Code) Encode(data []uint64) (map[uint64]uint64, error) {
 
 	// create polynomial from data.
 	p := field.NewPolynomial(f, paddedData, false)
+
 	// evaluate polynomial at n points.
+	return gao.EvaluationMap.EvaluatePolynomial(p)
+}
+
+var ErrTooManyMissingPoints = errors.New("too many missing points")
+var ErrTooManyPoints = errors.New("too many evaluated points")
+var ErrDecoding = errors.New("decoding error")
+var ErrCodewordSizeMismatch = errors.New("codeword and presence mask must be of length `n`")
 
-	ys, err := gao.EvaluationMap.EvaluatePolynomial(p)
+// Decode decodes received, treating missing evaluation points as erasures. received is not modified.
+func (gao *Code) Decode(received map[uint64]uint64) ([]uint64, error) {
+	xs, ys, present, err := gao.prepareDecoding(received)
 	if err != nil {
 		return nil, err
 	}
 
-	// create map of points.
-	xs := gao.EvaluationMap.EvaluationPoints(gao.N())
-	points := make(map[uint64]uint64, gao.N())
+	return gao.decode(xs, ys, present)
+}
 
-	for i, y := range ys {
-		points[xs[i]] = y
+// DecodeContext is Decode that can be cancelled, e.g., when the client of a server goes away mid-decode.
+// It checks ctx between the decoding stages and on each step of the partial EEA, which dominates large decodes,
+// and returns ctx.Err() once ctx is done. received is not modified.
+func (gao *Code) DecodeContext(ctx context.Context, received map[uint64]uint64) ([]uint64, error) {
+	xs, ys, present, err := gao.prepareDecoding(received)
+	if err != nil {
+		return nil, err
 	}
 
-	return points, nil
-}
+	f, err := gao.decodePolynomialWith(ctx, xs, ys, present, gao.interpolate)
+	if err != nil {
+		return nil, err
+	}
 
-var ErrTooManyMissingPoints = errors.New("too many missing points")
-var ErrTooManyPoints = errors.New("too many evaluated points")
-var ErrDecoding = errors.New("decoding error")
+	return f.ToSlice(), nil
+}
 
-func (gao *Code) Decode(received map[uint64]uint64) ([]uint64, error) {
-	// fill missing evaluated points with 0.
-	xs, ys, err := gao.prepareDecoding(received)
+// DecodePolynomial is Decode returning the recovered message polynomial, trimmed, instead of its coefficients.
+// It is owned by the caller, e.g., to evaluate it elsewhere or use it in further ring operations.
+func (gao *Code) DecodePolynomial(received map[uint64]uint64) (*field.Polynomial, error) {
+	xs, ys, present, err := gao.prepareDecoding(received)
 	if err != nil {
 		return nil, err
 	}
 
-	var f, r *field.Polynomial
-	if gao.EvaluationMap.isNTT() {
-		f, r, err = gao.decodeNTT(ys, xs)
-	} else {
-		f, r, err = gao.decodeGeneric(ys, xs)
+	return gao.decodePolynomialWith(context.Background(), xs, ys, present, gao.interpolate)
+}
+
+// DecodeBestEffort is Decode with a fallback: when unique decoding fails with ErrDecoding, it returns
+// the interpolant of received truncated to its k lowest coefficients, and exact = false.
+// The best-effort message is NOT guaranteed to be correct, nor to be the nearest codeword's message;
+// it is merely a guess (e.g., for soft-failure telemetry), so callers must check exact before trusting it.
+// Other errors (e.g., ErrTooManyMissingPoints) are returned as they are. received is not modified.
+func (gao *Code) DecodeBestEffort(received map[uint64]uint64) (msg []uint64, exact bool, err error) {
+	xs, ys, present, err := gao.prepareDecoding(received)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// the NTT interpolation transforms ys in place, hence decode a copy.
+	msg, err = gao.decode(xs, slices.Clone(ys), present)
+	if err == nil {
+		return msg, true, nil
+	}
+
+	if !errors.Is(err, ErrDecoding) {
+		return nil, false, err
+	}
+
+	g1, err := gao.interpolate(xs, ys)
+	if err != nil {
+		return nil, false, err
+	}
+
+	g1.Trim()
+	if inner := g1.NoCopySlice(); len(inner) > gao.K() {
+		return field.NewPolynomial(gao.PrimeField(), inner[:gao.K()], false).Trim().ToSlice(), false, nil
 	}
 
+	return g1.ToSlice(), false, nil
+}
+
+var ErrVerificationFailed = errors.New("decoded message re-encodes too far from the received word")
+
+// DecodeVerified is Decode that double-checks its result: it re-encodes the recovered message and returns
+// ErrVerificationFailed unless the codeword is within MaxErrors() of received, counting missing points as errors.
+// This guards against a wrong-but-plausible decode, at the cost of an encoding. received is not modified.
+func (gao *Code) DecodeVerified(received map[uint64]uint64) ([]uint64, error) {
+	xs, ys, present, err := gao.prepareDecoding(received)
 	if err != nil {
 		return nil, err
 	}
 
-	if !r.IsZero() || f.Degree() > gao.K() {
-		return nil, ErrDecoding
+	// the NTT interpolation transforms ys in place, hence decode a copy.
+	msg, err := gao.decode(xs, slices.Clone(ys), present)
+	if err != nil {
+		return nil, err
 	}
 
-	return f.ToSlice(), nil
+	if err := gao.verify(msg, ys, present); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// verify checks the encoding of msg is within MaxErrors() of ys, given in EvaluationPoints(n) order.
+func (gao *Code) verify(msg, ys []uint64, present []bool) error {
+	codeword, err := gao.EncodeOrdered(msg)
+	if err != nil {
+		return err
+	}
+
+	fld := gao.PrimeField()
+
+	dist := 0
+	for i, y := range codeword {
+		if !present[i] || !fld.Equals(y, ys[i]) {
+			dist++
+		}
+	}
+
+	if dist > gao.MaxErrors() {
+		return fmt.Errorf("%w: distance %d > %d", ErrVerificationFailed, dist, gao.MaxErrors())
+	}
+
+	return nil
+}
+
+// CountErrors returns how many symbols of received disagree with the encoding of decoded, e.g., the message
+// Decode recovered from it. Missing evaluation points aren't counted: they are erasures rather than errors,
+// and keys of received that aren't evaluation points are ignored. received is not modified.
+func (gao *Code) CountErrors(received map[uint64]uint64, decoded []uint64) (int, error) {
+	if len(received) > gao.N() {
+		return 0, ErrTooManyPoints
+	}
+
+	codeword, err := gao.EncodeOrdered(decoded)
+	if err != nil {
+		return 0, err
+	}
+
+	fld := gao.PrimeField()
+
+	numErrors := 0
+	for i, x := range gao.EvaluationMap.EvaluationPoints(gao.N()) {
+		if y, ok := received[x]; ok && !fld.Equals(y, codeword[i]) {
+			numErrors++
+		}
+	}
+
+	return numErrors, nil
 }
 
+var ErrInvalidPoint = errors.New("evaluation point must be a field element")
+
 /*
-prepare the decoding process by filling in missing evaluated points with zeros.
+DecodeArbitrary decodes m received points (x, y) whose x-values are any distinct field elements, rather than
+the EvaluationMap's EvaluationPoints(n): the locator \prod (x - x_i) and the interpolant are built from the
+received keys themselves, thus no erasures exist, and up to (m-k)/2 errors are corrected.
+It ignores the stop degree set by SetStopDegree. points is not modified.
 */
-func (gao *Code) prepareDecoding(toDecode map[uint64]uint64) ([]uint64, []uint64, error) {
-	if len(toDecode) > gao.N() {
-		return nil, nil, ErrTooManyPoints
+func (gao *Code) DecodeArbitrary(points map[uint64]uint64) ([]uint64, error) {
+	fld := gao.PrimeField()
+
+	xs := make([]uint64, 0, len(points))
+	for x := range points {
+		if x >= fld.Modulus() {
+			return nil, fmt.Errorf("%w: %d, field modulus is %d", ErrInvalidPoint, x, fld.Modulus())
+		}
+
+		xs = append(xs, x)
+	}
+
+	// map iteration order is random, sorting keeps decoding deterministic.
+	slices.Sort(xs)
+
+	m, k := len(xs), gao.K()
+	if m < k {
+		return nil, ErrTooManyMissingPoints
+	}
+
+	ys := make([]uint64, m)
+	for i, x := range xs {
+		ys[i] = fld.Reduce(points[x])
+	}
+
+	gao.lastPath.Store(int32(genericDecodePath))
+	gao.lastErrors.Store(-1)
+
+	// see decodeRawWith: a word close to the zero codeword defeats the partial EEA.
+	if nonZeros := gao.countNonZeros(ys); nonZeros <= (m-k)/2 {
+		gao.lastErrors.Store(int64(nonZeros))
+
+		return field.NewPolynomial(fld, []uint64{0}, false).Trim().ToSlice(), nil
+	}
+
+	g0 := field.VanishingPolynomial(gao.pr, xs)
+
+	g1, err := gao.interpolator.Interpolate(xs, ys)
+	if err != nil {
+		return nil, err
+	}
+
+	stopDegree := (m + k) / 2
+
+	g, _, v := gao.pr.PartialExtendedEuclidean(g0, g1, stopDegree)
+	if g.Degree() >= stopDegree {
+		return nil, ErrDecoding
+	}
+
+	f, r := gao.pr.LongDiv(g, v)
+	if !gao.isMessage(f, r) {
+		return nil, ErrDecoding
+	}
+
+	gao.lastErrors.Store(int64(v.Degree()))
+
+	return f.Trim().ToSlice(), nil
+}
+
+// DecodeOrdered decodes a codeword given in EvaluationPoints(n) order.
+// present[i] == false marks the i'th symbol as erased. A nil present means no erasures.
+func (gao *Code) DecodeOrdered(codeword []uint64, present []bool) ([]uint64, error) {
+	if len(codeword) != gao.N() || (present != nil && len(present) != gao.N()) {
+		return nil, ErrCodewordSizeMismatch
 	}
 
 	numMissing := 0
+	ys := make([]uint64, gao.N())
 
-	xs := gao.EvaluationMap.EvaluationPoints(gao.N())
-	for _, x := range xs {
-		if _, ok := toDecode[x]; !ok {
-			toDecode[x] = 0
+	for i, y := range codeword {
+		if present != nil && !present[i] {
 			numMissing += 1
+			continue
 		}
+
+		ys[i] = y
 	}
 
 	if numMissing > gao.MaxErrors() {
-		return nil, nil, ErrTooManyMissingPoints
+		return nil, ErrTooManyMissingPoints
 	}
 
-	ys := make([]uint64, gao.N())
-	for i, x := range xs {
-		ys[i] = toDecode[x] // according to the order of the EvaluationMap's EvaluationPoints.
-	}
+	return gao.decode(gao.EvaluationMap.EvaluationPoints(gao.N()), ys, present)
+}
 
-	return xs, ys, nil
+// LastDecodePath returns "ntt" or "generic" according to the path taken by the last decode,
+// or "" if the code hasn't decoded yet.
+func (gao *Code) LastDecodePath() string {
+	return decodePath(gao.lastPath.Load()).String()
 }
 
-func (gao *Code) decodeGeneric(ys []uint64, xs []uint64) (*field.Polynomial, *field.Polynomial, error) {
-	g1, err := gao.interpolator.Interpolate(xs, ys)
+// LastDecodeErrors returns the number of symbols the last decode corrected, erasures included,
+// or -1 if it failed or the code hasn't decoded yet.
+func (gao *Code) LastDecodeErrors() int {
+	return int(gao.lastErrors.Load())
+}
+
+// DecodeRaw runs the decoder on received without the acceptance checks of Decode, returning the recovered
+// polynomial f and the remainder r of g/v. Decode accepts only when r is zero and deg(f) < k;
+// advanced callers may apply their own criteria. Missing points are erasures, as in Decode.
+// err is set on invalid inputs, or when the partial EEA leaves no g/v to divide.
+func (gao *Code) DecodeRaw(received map[uint64]uint64) (f, r *field.Polynomial, err error) {
+	xs, ys, present, err := gao.prepareDecoding(received)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	pr := gao.pr
-
-	g, _, v := pr.PartialExtendedEuclidean(gao.g0, g1, gao.stopDegree)
-	f, r := pr.LongDiv(g, v)
+	f, r, _, err = gao.decodeRaw(xs, ys, present)
 
-	return f, r, nil
+	return f, r, err
 }
 
-func (gao *Code) decodeNTT(ys []uint64, xs []uint64) (*field.Polynomial, *field.Polynomial, error) {
-	g1 := field.NewPolynomial(gao.pr.GetField(), ys, true)
-	if err := gao.pr.NttBackward(g1); err != nil {
-		return nil, nil, err
+var ErrInvalidInterpolant = errors.New("interpolant must be in coefficient form and of degree < n")
+
+// DecodeFromInterpolant decodes given g1, the interpolant of the received word over EvaluationPoints(n),
+// skipping the interpolation step of Decode. g1 is not modified.
+// Unlike Decode, it can't spot received words close to the zero codeword, and may fail on them with ErrDecoding.
+func (gao *Code) DecodeFromInterpolant(g1 *field.Polynomial) ([]uint64, error) {
+	if g1 == nil || g1.IsNTT() || g1.Degree() >= gao.N() {
+		return nil, ErrInvalidInterpolant
 	}
 
-	pr := gao.pr
+	path := genericDecodePath
+	if gao.nttDomain() {
+		path = nttDecodePath
+	}
+
+	gao.lastPath.Store(int32(path))
+	gao.lastErrors.Store(-1)
+
+	if g1.Degree() < gao.K() {
+		gao.lastErrors.Store(0)
+
+		return g1.Copy().Trim().ToSlice(), nil
+	}
+
+	var f, r, v *field.Polynomial
+	var err error
+
+	if path == nttDecodePath {
+		f, r, v, err = gao.decodeNTT(context.Background(), g1)
+	} else {
+		f, r, v, err = gao.decodeGeneric(context.Background(), g1)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !gao.isMessage(f, r) {
+		return nil, ErrDecoding
+	}
+
+	gao.lastErrors.Store(int64(v.Degree()))
+
+	return f.ToSlice(), nil
+}
+
+// isMessage is the acceptance check of every decoder: g/v divides exactly into f, of degree < k.
+// Otherwise the received word is more than the correction radius away from any codeword.
+func (gao *Code) isMessage(f, r *field.Polynomial) bool {
+	return r.IsZero() && f.Degree() < gao.K()
+}
+
+func (gao *Code) decode(xs, ys []uint64, present []bool) ([]uint64, error) {
+	return gao.decodeWith(xs, ys, present, gao.interpolate)
+}
+
+func (gao *Code) decodeWith(xs, ys []uint64, present []bool,
+	interpolate func(xs, ys []uint64) (*field.Polynomial, error)) ([]uint64, error) {
+	f, err := gao.decodePolynomialWith(context.Background(), xs, ys, present, interpolate)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.ToSlice(), nil
+}
+
+// decodePolynomialWith is decodeWith returning f itself, and ctx.Err() once ctx is done.
+func (gao *Code) decodePolynomialWith(ctx context.Context, xs, ys []uint64, present []bool,
+	interpolate func(xs, ys []uint64) (*field.Polynomial, error)) (*field.Polynomial, error) {
+	f, r, numErrors, err := gao.decodeRawWith(ctx, xs, ys, present, interpolate)
+	if err != nil {
+		return nil, err
+	}
+
+	if !gao.isMessage(f, r) {
+		return nil, ErrDecoding
+	}
+
+	gao.lastErrors.Store(int64(numErrors))
+
+	return f.Trim(), nil
+}
+
+// decodeRaw returns f and r = g/v, and the number of corrected symbols, erasures included.
+// present[i] == false marks ys[i] as an erasure, whose value is 0; a nil present means no erasures.
+// Erasures are tracked apart from the values, since a genuine symbol may be 0 as well.
+func (gao *Code) decodeRaw(xs, ys []uint64, present []bool) (f, r *field.Polynomial, numErrors int, err error) {
+	return gao.decodeRawWith(context.Background(), xs, ys, present, gao.interpolate)
+}
+
+// decodeRawWith is decodeRaw with a custom interpolation of (xs, ys), e.g., into reused buffers.
+// It checks ctx between its stages and in the partial EEA, returning ctx.Err() once ctx is done.
+func (gao *Code) decodeRawWith(ctx context.Context, xs, ys []uint64, present []bool,
+	interpolate func(xs, ys []uint64) (*field.Polynomial, error)) (f, r *field.Polynomial, numErrors int, err error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, 0, err
+	}
+
+	numMissing := 0
+	for _, ok := range present {
+		if !ok {
+			numMissing++
+		}
+	}
+
+	path := genericDecodePath
+	if gao.nttDomain() {
+		path = nttDecodePath
+	}
+
+	gao.lastPath.Store(int32(path))
+	gao.lastErrors.Store(-1)
+
+	// When the received word is close to the zero codeword (at most MaxErrors non-zeros), g1 vanishes
+	// on most evaluation points, thus it shares a factor of degree >= stopDegree with g0. The partial EEA
+	// then stops on their gcd instead of reaching Gao's g, so we handle this case before running it.
+	if nonZeros := gao.countNonZeros(ys); nonZeros <= gao.MaxErrors() {
+		fld := gao.PrimeField()
+		zero := field.NewPolynomial(fld, []uint64{0}, false)
+
+		// the zero-filled erasures are zeros too; the non-zeros are errors.
+		return zero, zero.Copy(), nonZeros + numMissing, nil
+	}
+
+	start := gao.traceStart()
+
+	g1, err := interpolate(xs, ys)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	if gao.tracer != nil {
+		gao.tracer.OnInterpolate(time.Since(start), g1.Degree())
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, nil, 0, err
+	}
+
+	// In the common case of no erasures and no errors, g1 is the message itself and the partial EEA is skipped.
+	if numMissing == 0 && g1.Degree() < gao.K() {
+		return g1.Trim(), field.NewPolynomial(gao.PrimeField(), []uint64{0}, false), 0, nil
+	}
+
+	var v *field.Polynomial
+	if gao.usesPGZ() {
+		var ok bool
+		if f, v, ok = gao.decodePGZ(xs, g1); ok {
+			r = field.NewPolynomial(gao.PrimeField(), []uint64{0}, false)
+		}
+	}
+
+	// the partial EEA also reports the failures of decodePGZ, for DecodeRaw's sake.
+	switch {
+	case v != nil:
+	case path == nttDecodePath:
+		f, r, v, err = gao.decodeNTT(ctx, g1)
+	default:
+		f, r, v, err = gao.decodeGeneric(ctx, g1)
+	}
+
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	// v is the error locator: its roots are the corrupted evaluation points, including the erasures
+	// whose symbol wasn't 0. Count each erasure once, whether or not v located it.
+	numErrors = v.Degree() + numMissing
+	for i, ok := range present {
+		if !ok && gao.pr.EvaluateAny(v, xs[i]) == 0 {
+			numErrors--
+		}
+	}
+
+	return f, r, numErrors, nil
+}
+
+var ErrUnknownErasure = errors.New("erased point is not an evaluation point")
+
+// CorrectableErrors returns how many errors DecodeWithErasures corrects besides numErasures known erasures,
+// (n-k-numErasures)/2, or -1 if it can't decode at all, i.e., with more than n-k erasures.
+// Decode, which zero-fills erasures, spends an error on each instead: it corrects MaxErrors()-numErasures errors.
+func (gao *Code) CorrectableErrors(numErasures int) int {
+	if numErasures < 0 || numErasures > gao.N()-gao.K() {
+		return -1
+	}
+
+	return (gao.N() - gao.K() - numErasures) / 2
+}
+
+// DecodeWithErasures decodes received, where the points in erased (and the ones missing from received) are
+// known erasures. Unlike Decode, which zero-fills them and thus spends an error on each, erasures are removed
+// from the system: decoding runs over the remaining n' points, as a code of length n' and data size k.
+// It corrects up to (n' - k)/2 errors, i.e., an erasure costs half an error.
+// The remaining points aren't an NTT domain, so this always takes the generic path. received is not modified.
+func (gao *Code) DecodeWithErasures(received map[uint64]uint64, erased []uint64) ([]uint64, error) {
+	if len(received) > gao.N() {
+		return nil, ErrTooManyPoints
+	}
+
+	gao.lastPath.Store(int32(genericDecodePath))
+	gao.lastErrors.Store(-1)
+
+	allXs := gao.EvaluationMap.EvaluationPoints(gao.N())
+
+	isErased := make(map[uint64]bool, len(erased))
+	for _, x := range erased {
+		isErased[x] = true
+	}
+
+	xs := make([]uint64, 0, gao.N())
+	ys := make([]uint64, 0, gao.N())
+
+	for _, x := range allXs {
+		y, ok := received[x]
+		if !ok || isErased[x] {
+			delete(isErased, x)
+			continue
+		}
+
+		xs = append(xs, x)
+		ys = append(ys, y)
+	}
+
+	if len(isErased) > 0 {
+		return nil, ErrUnknownErasure
+	}
+
+	if len(xs) < gao.K() {
+		return nil, ErrTooManyMissingPoints
+	}
+
+	maxErrors := (len(xs) - gao.K()) / 2
+	if nonZeros := gao.countNonZeros(ys); nonZeros <= maxErrors {
+		gao.lastErrors.Store(int64(nonZeros))
+
+		return []uint64{0}, nil
+	}
+
+	start := gao.traceStart()
+
+	g1, err := gao.interpolator.Interpolate(xs, ys)
+	if err != nil {
+		return nil, err
+	}
+
+	if gao.tracer != nil {
+		gao.tracer.OnInterpolate(time.Since(start), g1.Degree())
+	}
+
+	pr := gao.pr
+	stopDegree := (len(xs) + gao.K()) / 2
+	g0 := field.PolyProductMonicNegRoots(pr.GetField(), xs)
+
+	start = gao.traceStart()
+	g, _, v := pr.PartialExtendedEuclidean(g0, g1, stopDegree)
+	gao.traceEEA(start, g, v)
+
+	if g.Degree() >= stopDegree {
+		return nil, ErrDecoding
+	}
+
+	start = gao.traceStart()
+	f, r := pr.LongDiv(g, v)
+	gao.traceDivision(start, f, r)
+
+	if !gao.isMessage(f, r) {
+		return nil, ErrDecoding
+	}
+
+	gao.lastErrors.Store(int64(v.Degree()))
+
+	return f.ToSlice(), nil
+}
+
+/*
+prepare the decoding process without modifying toDecode: missing evaluated points are marked as not present,
+and their values are zero-filled.
+returns the evaluation points, their values (according to the order of the EvaluationMap's EvaluationPoints)
+and which of them are present.
+*/
+func (gao *Code) prepareDecoding(toDecode map[uint64]uint64) ([]uint64, []uint64, []bool, error) {
+	if len(toDecode) > gao.N() {
+		return nil, nil, nil, ErrTooManyPoints
+	}
+
+	xs := gao.EvaluationMap.EvaluationPoints(gao.N())
+	ys := make([]uint64, gao.N())
+	present := make([]bool, gao.N())
+
+	numMissing := fillReceived(toDecode, xs, ys, present)
+	if numMissing > gao.MaxErrors() {
+		return nil, nil, nil, ErrTooManyMissingPoints
+	}
+
+	return xs, ys, present, nil
+}
+
+// fillReceived sets ys[i] and present[i] according to received[xs[i]], and returns the number of missing points.
+func fillReceived(received map[uint64]uint64, xs, ys []uint64, present []bool) int {
+	numMissing := 0
+
+	for i, x := range xs {
+		ys[i], present[i] = received[x] // 0 when missing.
+		if !present[i] {
+			numMissing++
+		}
+	}
+
+	return numMissing
+}
+
+// countNonZeros returns the number of non-zero received values.
+func (gao *Code) countNonZeros(ys []uint64) int {
+	fld := gao.PrimeField()
+
+	nonZeros := 0
+	for _, y := range ys {
+		if fld.Reduce(y) != 0 {
+			nonZeros += 1
+		}
+	}
+
+	return nonZeros
+}
+
+var ErrIncompleteCodeword = errors.New("codeword is missing evaluated points")
+
+// IsValidCodeword reports whether received is an uncorrupted codeword, namely,
+// whether its interpolant is of degree < k. This is cheaper than Decode since it skips error correction.
+// received must contain all n evaluation points, and is not modified.
+func (gao *Code) IsValidCodeword(received map[uint64]uint64) (bool, error) {
+	g1, err := gao.codewordInterpolant(received)
+	if err != nil {
+		return false, err
+	}
+
+	return g1.Degree() < gao.K(), nil
+}
+
+// codewordInterpolant returns the interpolant of received, which must contain all n evaluation points.
+func (gao *Code) codewordInterpolant(received map[uint64]uint64) (*field.Polynomial, error) {
+	if len(received) > gao.N() {
+		return nil, ErrTooManyPoints
+	}
+
+	fld := gao.PrimeField()
+	xs := gao.EvaluationMap.EvaluationPoints(gao.N())
+	ys := make([]uint64, len(xs))
+
+	for i, x := range xs {
+		y, ok := received[x]
+		if !ok {
+			return nil, ErrIncompleteCodeword
+		}
+
+		ys[i] = fld.Reduce(y)
+	}
+
+	return gao.interpolate(xs, ys)
+}
+
+var (
+	ErrNotACodeword   = errors.New("received word is not an uncorrupted codeword")
+	ErrDuplicatePoint = errors.New("point is already part of the codeword")
+)
+
+/*
+ExtendParity returns codeword with extra parity symbols: the evaluations of its message polynomial at
+extraPoints, which must be field elements that aren't evaluation points of codeword, nor repeat.
+This lowers the rate of an existing encoding without re-encoding the data, e.g., when a channel turns noisier.
+
+The precondition is that codeword is clean: it contains all n evaluation points, and is uncorrupted,
+otherwise ExtendParity returns ErrIncompleteCodeword or ErrNotACodeword. Decode it first if need be.
+The extended codeword has n+len(extraPoints) points, correcting (n+len(extraPoints)-k)/2 errors with
+DecodeArbitrary, or with Decode of a longer code whose evaluation points they are. codeword is not modified.
+*/
+func (gao *Code) ExtendParity(codeword map[uint64]uint64, extraPoints []uint64) (map[uint64]uint64, error) {
+	fld := gao.PrimeField()
+
+	extended := make(map[uint64]uint64, len(codeword)+len(extraPoints))
+	for x, y := range codeword {
+		extended[x] = y
+	}
+
+	for _, x := range extraPoints {
+		if x >= fld.Modulus() {
+			return nil, fmt.Errorf("%w: %d, field modulus is %d", ErrInvalidPoint, x, fld.Modulus())
+		}
+
+		if _, ok := extended[x]; ok {
+			return nil, fmt.Errorf("%w: %d", ErrDuplicatePoint, x)
+		}
+
+		extended[x] = 0 // marks x as taken, its value is set below.
+	}
+
+	f, err := gao.codewordInterpolant(codeword)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.Degree() >= gao.K() {
+		return nil, ErrNotACodeword
+	}
+
+	for _, x := range extraPoints {
+		extended[x] = gao.pr.Evaluate(f, x)
+	}
+
+	return extended, nil
+}
+
+// Distance returns the Hamming distance between two codewords, i.e., the number of evaluation points
+// on which their values differ. Both must contain exactly the code's evaluation points.
+func (gao *Code) Distance(a, b map[uint64]uint64) (int, error) {
+	if len(a) > gao.N() || len(b) > gao.N() {
+		return 0, ErrTooManyPoints
+	}
+
+	fld := gao.PrimeField()
+	dist := 0
+
+	for _, x := range gao.EvaluationMap.EvaluationPoints(gao.N()) {
+		ya, okA := a[x]
+		yb, okB := b[x]
+
+		if !okA || !okB {
+			return 0, ErrIncompleteCodeword
+		}
+
+		if !fld.Equals(ya, yb) {
+			dist++
+		}
+	}
+
+	return dist, nil
+}
+
+/*
+Syndromes returns the n-k syndromes of received, which must contain all n evaluation points:
+S_j = \sum_i w_i * y_i * x_i^j for j = 0, ..., n-k-1, where w_i = 1/g0'(x_i) = 1/\prod_{l != i} (x_i - x_l).
+
+They are all zero iff received is a codeword: \sum_i w_i * h(x_i) is the coefficient of x^(n-1) in the
+interpolant of h, thus it vanishes for every h = f * x^j with deg(f) < k. The weights w_i are needed for
+arbitrary evaluation points; over the n'th roots of unity they are x_i/n.
+received is not modified.
+*/
+func (gao *Code) Syndromes(received map[uint64]uint64) ([]uint64, error) {
+	if len(received) > gao.N() {
+		return nil, ErrTooManyPoints
+	}
+
+	fld := gao.PrimeField()
+	xs := gao.EvaluationMap.EvaluationPoints(gao.N())
+	weights := gao.syndromeWeights(xs)
+
+	syndromes := make([]uint64, gao.N()-gao.K())
+	for i, x := range xs {
+		y, ok := received[x]
+		if !ok {
+			return nil, ErrIncompleteCodeword
+		}
+
+		// w_i * y_i * x_i^j.
+		t := fld.Mul(weights[i], fld.Reduce(y))
+		for j := range syndromes {
+			syndromes[j] = fld.Add(syndromes[j], t)
+			t = fld.Mul(t, x)
+		}
+	}
+
+	return syndromes, nil
+}
+
+// LocatorDerivativeEval returns g0'(x), the derivative of the locator polynomial at x, e.g., for Forney-style
+// correctors: the evaluation points are simple roots of g0, so g0' doesn't vanish on them.
+// The derivative is computed once per code, in sparse form when g0 is sparse.
+func (gao *Code) LocatorDerivativeEval(x uint64) uint64 {
+	gao.g0DerivOnce.Do(func() {
+		if gao.g0Sparse != nil {
+			gao.g0Deriv = gao.g0Sparse.Derivative().Evaluate
+
+			return
+		}
+
+		deriv := &field.Polynomial{}
+		gao.pr.Derivative(gao.locator(), deriv)
+		gao.g0Deriv = func(x uint64) uint64 { return gao.pr.Evaluate(deriv, x) }
+	})
+
+	return gao.g0Deriv(x)
+}
+
+/*
+ErrorEvaluator returns the error-evaluator polynomial Ω of received, for Forney-style correctors: with the monic
+error locator Λ(x) = ∏(x - x_j) over the corrupted points x_j, the error magnitude at x_j is
+
+	e_j = received(x_j) - codeword(x_j) = Ω(x_j) / Λ'(x_j),
+
+and deg(Ω) < deg(Λ). Missing points are zero-filled, as in Decode. It returns ErrDecoding if received is
+more than MaxErrors() away from any codeword.
+
+Ω comes from the cofactors of the partial EEA, u*g0 + v*g1 = g, where v = c*Λ and g = v*f for the message f:
+thus u*g0 = -c*Λ*E for the error interpolant E = g1 - f, and differentiating at a root x_j of both g0 and Λ
+gives u(x_j)*g0'(x_j) = -c*Λ'(x_j)*e_j. Hence Ω = -u*g0'/c mod Λ.
+received is not modified.
+*/
+func (gao *Code) ErrorEvaluator(received map[uint64]uint64) (*field.Polynomial, error) {
+	xs, ys, _, err := gao.prepareDecoding(received)
+	if err != nil {
+		return nil, err
+	}
+
+	// the partial EEA fails near the zero codeword, see decodeRawWith.
+	if gao.countNonZeros(ys) <= gao.MaxErrors() {
+		return gao.nearZeroErrorEvaluator(xs, ys)
+	}
+
+	g1, err := gao.interpolate(xs, ys)
+	if err != nil {
+		return nil, err
+	}
+
+	pr := gao.pr
+	g0 := gao.locator()
+
+	g, u, v := pr.PartialExtendedEuclidean(g0, g1, gao.stopDegree)
+	if g.Degree() >= gao.stopDegree {
+		return nil, ErrDecoding
+	}
+
+	if f, r := pr.LongDiv(g, v); !gao.isMessage(f, r) {
+		return nil, ErrDecoding
+	}
+
+	lambda, c := pr.Monic(v)
+	if lambda.Degree() == 0 {
+		// no errors, thus u = 0.
+		return field.NewPolynomial(gao.PrimeField(), []uint64{0}, false), nil
+	}
+
+	deriv := &field.Polynomial{}
+	pr.Derivative(g0, deriv)
+	_, deriv = pr.LongDiv(deriv, lambda)
+
+	omega := &field.Polynomial{}
+	pr.MulPoly(u, deriv, omega)
+	_, omega = pr.LongDiv(omega, lambda)
+
+	fld := gao.PrimeField()
+	pr.MulScalarInPlace(omega, fld.Neg(fld.Inverse(c)))
+
+	return omega, nil
+}
+
+// nearZeroErrorEvaluator returns Ω for ys within MaxErrors of the zero codeword: its non-zero symbols are the
+// errors, thus Ω interpolates e_j * Λ'(x_j) over them.
+func (gao *Code) nearZeroErrorEvaluator(xs, ys []uint64) (*field.Polynomial, error) {
+	fld := gao.PrimeField()
+
+	var errXs, errYs []uint64
+	for i, y := range ys {
+		if y = fld.Reduce(y); y != 0 {
+			errXs = append(errXs, xs[i])
+			errYs = append(errYs, y)
+		}
+	}
+
+	if len(errXs) == 0 {
+		return field.NewPolynomial(fld, []uint64{0}, false), nil
+	}
+
+	deriv := &field.Polynomial{}
+	gao.pr.Derivative(field.VanishingPolynomial(gao.pr, errXs), deriv)
+
+	for i, x := range errXs {
+		errYs[i] = fld.Mul(errYs[i], gao.pr.Evaluate(deriv, x))
+	}
+
+	return gao.interpolator.Interpolate(errXs, errYs)
+}
+
+// syndromeWeights returns 1/g0'(x_i) for the evaluation points, computed once per code.
+func (gao *Code) syndromeWeights(xs []uint64) []uint64 {
+	gao.dualWeightsOnce.Do(func() {
+		fld := gao.PrimeField()
+
+		weights := make([]uint64, len(xs))
+		for i, x := range xs {
+			weights[i] = fld.Inverse(gao.LocatorDerivativeEval(x)) // non-zero, since the roots of g0 are simple.
+		}
+
+		gao.dualWeights = weights
+	})
+
+	return gao.dualWeights
+}
+
+// domainInterpolator returns the interpolation over EvaluationPoints(n), whose O(n^2) precomputation,
+// inverting the Lagrange denominators, happens once per code instead of on every decode.
+func (gao *Code) domainInterpolator() (*field.DomainInterpolator, error) {
+	gao.domainInterpOnce.Do(func() {
+		gao.domainInterp, gao.domainInterpErr = field.NewDomainInterpolator(gao.pr, gao.EvaluationMap.EvaluationPoints(gao.N()))
+	})
+
+	return gao.domainInterp, gao.domainInterpErr
+}
+
+var ErrKMismatch = errors.New("codes must have the same data size `k`")
+
+// SymbolOverflowError reports the first symbol that isn't smaller than a field's modulus: a data element
+// to encode, or a decoded symbol that doesn't fit the target field of Transcode.
+// errors.Is(err, ErrDataElementsTooLarge) holds for it.
+type SymbolOverflowError struct {
+	Index   int
+	Value   uint64
+	Modulus uint64
+}
+
+func (e *SymbolOverflowError) Error() string {
+	return fmt.Sprintf("%v: symbol %d is %d, field modulus is %d", ErrDataElementsTooLarge, e.Index, e.Value, e.Modulus)
+}
+
+func (e *SymbolOverflowError) Unwrap() error {
+	return ErrDataElementsTooLarge
+}
+
+// Transcode decodes received under gao and re-encodes the message under target,
+// e.g., to migrate codewords to a larger prime. Both codes must have the same k.
+// Moving to a smaller prime fails with a *SymbolOverflowError on the first symbol that doesn't fit.
+// Missing points are erasures, as in Decode.
+func (gao *Code) Transcode(received map[uint64]uint64, target *Code) (map[uint64]uint64, error) {
+	if gao.K() != target.K() {
+		return nil, ErrKMismatch
+	}
+
+	data, err := gao.Decode(received)
+	if err != nil {
+		return nil, err
+	}
+
+	q := target.PrimeField().Modulus()
+	for i, d := range data {
+		if d >= q {
+			return nil, &SymbolOverflowError{Index: i, Value: d, Modulus: q}
+		}
+	}
+
+	return target.Encode(data)
+}
+
+// interpolate returns the polynomial g1 such that g1(xs[i]) = ys[i], where xs are EvaluationPoints(n).
+// Over an NTT domain (see nttDomain) ys is transformed in place.
+func (gao *Code) interpolate(xs, ys []uint64) (*field.Polynomial, error) {
+	if !gao.nttDomain() {
+		interp, err := gao.domainInterpolator()
+		if err != nil {
+			return nil, err
+		}
+
+		return interp.Interpolate(ys)
+	}
+
+	if im, ok := gao.EvaluationMap.(interpolatingMap); ok {
+		return im.interpolate(ys)
+	}
+
+	g1 := field.NewPolynomial(gao.pr.GetField(), ys, true)
+	if err := g1.ValidateNttLength(); err != nil {
+		return nil, err
+	}
+
+	if err := gao.pr.NttBackward(g1); err != nil {
+		return nil, err
+	}
+
+	return g1, nil
+}
+
+func (gao *Code) decodeGeneric(ctx context.Context, g1 *field.Polynomial) (f, r, v *field.Polynomial, err error) {
+	pr := gao.pr
+
+	g0 := gao.locator()
+	start := gao.traceStart()
+
+	var g *field.Polynomial
+	if g, _, v, err = pr.PartialExtendedEuclideanContext(ctx, g0, g1, gao.stopDegree); err != nil {
+		return nil, nil, nil, err
+	}
+
+	gao.traceEEA(start, g, v)
+
+	if g.Degree() >= gao.stopDegree {
+		// gcd(g0, g1) is too large: no codeword within MaxErrors.
+		return nil, nil, nil, ErrDecoding
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, nil, nil, err
+	}
+
+	start = gao.traceStart()
+	f, r = pr.LongDiv(g, v)
+	gao.traceDivision(start, f, r)
+
+	return f, r, v, nil
+}
+
+func (gao *Code) decodeNTT(ctx context.Context, g1 *field.Polynomial) (f, r, v *field.Polynomial, err error) {
+	pr := gao.pr
+
+	g0 := gao.locator()
+	start := gao.traceStart()
+
+	var g *field.Polynomial
+	if g, _, v, err = pr.NttPartialExtendedEuclideanContext(ctx, g0, g1, gao.stopDegree); err != nil {
+		return nil, nil, nil, err
+	}
+
+	gao.traceEEA(start, g, v)
+
+	if g.Degree() >= gao.stopDegree {
+		// gcd(g0, g1) is too large: no codeword within MaxErrors.
+		return nil, nil, nil, ErrDecoding
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, nil, nil, err
+	}
 
-	g, _, v := pr.NttPartialExtendedEuclidean(gao.g0, g1, gao.stopDegree)
-	f, r := pr.LongDivNTT(g, v)
+	start = gao.traceStart()
+	f, r = pr.LongDivNTT(g, v)
+	gao.traceDivision(start, f, r)
 
-	return f, r, nil
+	return f, r, v, nil
 }
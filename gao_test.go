@@ -1,12 +1,17 @@
 package gao
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"maps"
 	"math/rand"
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/jonathanmweiss/go-gao/field"
+	"github.com/jonathanmweiss/go-gao/gaotest"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -29,38 +34,1619 @@ func TestNoCorruptions(t *testing.T) {
 	f, err := field.NewPrimeField(65537)
 	a.NoError(err)
 
+	coset, err := NewCosetNttEvaluator(f, f.Generator())
+	a.NoError(err)
+
+	testCases := []testCase{
+		{NewSlowEvaluator(f), 18, 5},
+		{NewNttEvaluator(f), 16, 4}, // checking non powers of 2.
+		{coset, 16, 4},
+		{coset, 64, 20},
+	}
+
+	for _, tc := range testCases {
+
+		prms, err := NewCodeParameters(tc.EvaluationMap, tc.n, tc.k)
+		a.NoError(err)
+
+		gao := NewCodeGao(prms)
+
+		encoded, err := gao.Encode(makeTestSlice(tc.k))
+		a.NoError(err)
+
+		// no corruptions
+		decoded, err := gao.Decode(encoded)
+		a.NoError(err)
+
+		a.Equal(makeTestSlice(tc.k), decoded)
+	}
+
+}
+
+func TestErasures(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	testCases := []testCase{
+		{NewSlowEvaluator(f), 18, 5},
+		{NewNttEvaluator(f), 16, 4}, // checking non powers of 2.
+	}
+
+	for _, tc := range testCases {
+		prms, err := NewCodeParameters(tc.EvaluationMap, tc.n, tc.k)
+		a.NoError(err)
+
+		gao := NewCodeGao(prms)
+
+		encoded, err := gao.Encode(makeTestSlice(tc.k))
+		a.NoError(err)
+
+		// add erasures
+		shuffledXs := shuffle(prms.EvaluationPoints(prms.n))
+		for i := 0; i < prms.MaxErrors(); i++ {
+			delete(encoded, shuffledXs[i])
+		}
+
+		a.Greater(prms.N(), len(encoded))
+
+		decoded, err := gao.Decode(encoded)
+		a.NoError(err)
+
+		a.Equal(makeTestSlice(tc.k), decoded)
+	}
+}
+
+func shuffle(slc []uint64) []uint64 {
+	rnd := rand.New(rand.NewSource(time.Now().Unix()))
+
+	cpy := make([]uint64, len(slc))
+	copy(cpy, slc)
+
+	rnd.Shuffle(len(slc), func(i, j int) {
+		cpy[i], cpy[j] = cpy[j], cpy[i]
+	})
+
+	return cpy
+}
+
+func TestCorruptions(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	coset, err := NewCosetNttEvaluator(f, f.Generator())
+	a.NoError(err)
+
+	testCases := []testCase{
+		{NewSlowEvaluator(f), 18, 5},
+		{NewNttEvaluator(f), 16, 4}, // checking non powers of 2.
+		{coset, 16, 4},
+		{coset, 64, 20},
+		// decoded by decodePGZ.
+		{NewSlowEvaluator(f), 18, 14},
+		{NewSlowEvaluator(f), 17, 14},
+		{NewNttEvaluator(f), 16, 12},
+		{coset, 16, 14},
+	}
+
+	for _, tc := range testCases {
+		prms, err := NewCodeParameters(tc.EvaluationMap, tc.n, tc.k)
+		a.NoError(err)
+
+		gao := NewCodeGao(prms)
+
+		encoded, err := gao.Encode(makeTestSlice(tc.k))
+		a.NoError(err)
+
+		corrupted := make(map[uint64]uint64, len(encoded))
+		for x, y := range encoded {
+			corrupted[x] = y
+		}
+
+		// add corruptions
+		shuffledXs := shuffle(prms.EvaluationPoints(prms.n))
+		for i := 0; i < prms.MaxErrors(); i++ {
+			corrupted[shuffledXs[i]] = rand.Uint64()
+		}
+
+		a.Len(corrupted, prms.N())
+		a.NotEqual(encoded, corrupted)
+
+		decoded, err := gao.Decode(corrupted)
+		a.NoError(err)
+
+		a.Equal(makeTestSlice(tc.k), decoded)
+	}
+}
+
+func TestBinaryFieldCorruptions(t *testing.T) {
+	a := assert.New(t)
+
+	for _, m := range []uint{8, 16} {
+		f, err := field.NewBinaryField(m)
+		a.NoError(err)
+
+		for _, nk := range [][2]int{{18, 5}, {255, 200}} {
+			prms, err := NewCodeParameters(NewSlowEvaluator(f), nk[0], nk[1])
+			a.NoError(err)
+
+			gao := NewCodeGao(prms)
+
+			data := make([]uint64, prms.K())
+			for i := range data {
+				data[i] = uint64(i*31+7) % f.Modulus()
+			}
+
+			encoded, err := gao.Encode(data)
+			a.NoError(err)
+
+			// half the budget in erasures, the rest in errors.
+			shuffledXs := shuffle(prms.EvaluationPoints(prms.N()))
+			numErasures := prms.MaxErrors() / 2
+			for i, x := range shuffledXs[:prms.MaxErrors()] {
+				if i < numErasures {
+					delete(encoded, x)
+					continue
+				}
+
+				encoded[x] ^= uint64(i + 1)
+			}
+
+			decoded, err := gao.Decode(encoded)
+			a.NoError(err, "m=%d n=%d k=%d", m, prms.N(), prms.K())
+			a.Equal(data, decoded)
+		}
+	}
+}
+
+func TestDecodeWithErasures(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	testCases := []testCase{
+		{NewSlowEvaluator(f), 32, 8},
+		{NewNttEvaluator(f), 32, 8},
+	}
+
+	for _, tc := range testCases {
+		prms, err := NewCodeParameters(tc.EvaluationMap, tc.n, tc.k)
+		a.NoError(err)
+
+		gao := NewCodeGao(prms)
+
+		encoded, err := gao.Encode(makeTestSlice(tc.k))
+		a.NoError(err)
+
+		// 10 erasures leave n' = 22 points, correcting (22 - 8)/2 = 7 errors.
+		// Decode would need 17 > MaxErrors() = 12 corrections.
+		shuffledXs := shuffle(prms.EvaluationPoints(tc.n))
+		erased := shuffledXs[:10]
+		for _, x := range shuffledXs[10:17] {
+			encoded[x] = f.Add(encoded[x], 1)
+		}
+
+		for _, x := range erased {
+			encoded[x] = 0
+		}
+
+		for _, x := range erased[:5] {
+			delete(encoded, x) // missing points are erasures too.
+		}
+
+		decoded, err := gao.DecodeWithErasures(encoded, erased[5:])
+		a.NoError(err)
+		a.Equal(makeTestSlice(tc.k), decoded)
+		a.Equal(7, gao.LastDecodeErrors())
+		a.Len(encoded, tc.n-5) // not modified.
+
+		decoded, err = gao.Decode(copyPoints(encoded))
+		a.False(err == nil && assert.ObjectsAreEqual(makeTestSlice(tc.k), decoded))
+
+		// one more error is beyond the budget.
+		encoded[shuffledXs[17]] = f.Add(encoded[shuffledXs[17]], 1)
+
+		decoded, err = gao.DecodeWithErasures(encoded, erased)
+		a.False(err == nil && assert.ObjectsAreEqual(makeTestSlice(tc.k), decoded))
+
+		_, err = gao.DecodeWithErasures(encoded, []uint64{0}) // 0 is never an evaluation point here.
+		a.ErrorIs(err, ErrUnknownErasure)
+
+		_, err = gao.DecodeWithErasures(encoded, shuffledXs[:25])
+		a.ErrorIs(err, ErrTooManyMissingPoints)
+	}
+}
+
+func TestCorrectableErrors(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	prms, err := NewCodeParameters(NewSlowEvaluator(f), 32, 8)
+	a.NoError(err)
+
+	gao := NewCodeGao(prms)
+
+	a.Equal(prms.MaxErrors(), gao.CorrectableErrors(0))
+	a.Equal(7, gao.CorrectableErrors(10))
+	a.Equal(0, gao.CorrectableErrors(23))
+	a.Equal(0, gao.CorrectableErrors(24)) // n-k erasures leave exactly k points.
+	a.Equal(-1, gao.CorrectableErrors(25))
+	a.Equal(-1, gao.CorrectableErrors(-1))
+
+	// the bound is tight: DecodeWithErasures corrects CorrectableErrors(e) errors besides e erasures.
+	xs := prms.EvaluationPoints(prms.N())
+	for _, numErasures := range []int{0, 5, 10, 23, 24} {
+		encoded, err := gao.Encode(makeTestSlice(prms.K()))
+		a.NoError(err)
+
+		numErrors := gao.CorrectableErrors(numErasures)
+		for _, x := range xs[numErasures : numErasures+numErrors] {
+			encoded[x] = f.Add(encoded[x], 1)
+		}
+
+		decoded, err := gao.DecodeWithErasures(encoded, xs[:numErasures])
+		a.NoError(err, "erasures=%d", numErasures)
+		a.Equal(makeTestSlice(prms.K()), decoded)
+	}
+
+	_, err = gao.DecodeWithErasures(copyPoints(map[uint64]uint64{}), xs[:25])
+	a.ErrorIs(err, ErrTooManyMissingPoints)
+}
+
+func TestDecodeFromInterpolant(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	testCases := []testCase{
+		{NewSlowEvaluator(f), 18, 5},
+		{NewNttEvaluator(f), 16, 4},
+	}
+
+	interpolator := field.NewInterpolator(field.NewDensePolyRing(f))
+
+	for _, tc := range testCases {
+		prms, err := NewCodeParameters(tc.EvaluationMap, tc.n, tc.k)
+		a.NoError(err)
+
+		gao := NewCodeGao(prms)
+		xs := prms.EvaluationPoints(tc.n)
+
+		for numErrors := 0; numErrors <= prms.MaxErrors()+1; numErrors++ {
+			codeword, err := gao.EncodeOrdered(makeTestSlice(tc.k))
+			a.NoError(err)
+
+			for i := 0; i < numErrors; i++ {
+				codeword[2*i] = f.Add(codeword[2*i], uint64(i+1))
+			}
+
+			g1, err := interpolator.Interpolate(xs, codeword)
+			a.NoError(err)
+
+			g1Copy := g1.Copy()
+
+			expected, expectedErr := gao.DecodeOrdered(codeword, nil)
+			decoded, err := gao.DecodeFromInterpolant(g1)
+
+			a.Equal(expectedErr, err, "numErrors=%d", numErrors)
+			a.Equal(expected, decoded, "numErrors=%d", numErrors)
+			a.True(g1Copy.Equals(g1)) // not modified.
+		}
+
+		_, err = gao.DecodeFromInterpolant(field.NewPolynomial(f, make([]uint64, tc.n+1), true))
+		a.ErrorIs(err, ErrInvalidInterpolant)
+
+		tooLong := make([]uint64, tc.n+1)
+		tooLong[tc.n] = 1
+
+		_, err = gao.DecodeFromInterpolant(field.NewPolynomial(f, tooLong, false))
+		a.ErrorIs(err, ErrInvalidInterpolant)
+	}
+}
+
+// x^k isn't a message, yet it is exactly divisible: g/v = x^k with a zero remainder. Every decoder must reject it.
+func TestDecodeRejectsDegreeK(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	testCases := []testCase{
+		{NewSlowEvaluator(f), 16, 4},
+		{NewNttEvaluator(f), 16, 4},
+	}
+
+	for _, tc := range testCases {
+		prms, err := NewCodeParameters(tc.EvaluationMap, tc.n, tc.k)
+		a.NoError(err)
+
+		gao := NewCodeGao(prms)
+		xs := prms.EvaluationPoints(tc.n)
+
+		received := map[uint64]uint64{}
+		codeword := make([]uint64, tc.n)
+
+		for i, x := range xs {
+			codeword[i] = f.Pow(x, uint64(tc.k))
+			received[x] = codeword[i]
+		}
+
+		_, err = gao.Decode(received)
+		a.ErrorIs(err, ErrDecoding)
+
+		_, err = gao.DecodeOrdered(codeword, nil)
+		a.ErrorIs(err, ErrDecoding)
+
+		_, err = gao.NewSession().Decode(received)
+		a.ErrorIs(err, ErrDecoding)
+
+		_, err = gao.DecodeContext(context.Background(), received)
+		a.ErrorIs(err, ErrDecoding)
+
+		xk := make([]uint64, tc.k+1)
+		xk[tc.k] = 1
+
+		_, err = gao.DecodeFromInterpolant(field.NewPolynomial(f, xk, false))
+		a.ErrorIs(err, ErrDecoding)
+
+		_, err = gao.DecodeWithErasures(received, nil)
+		a.ErrorIs(err, ErrDecoding)
+
+		_, err = gao.DecodeArbitrary(received)
+		a.ErrorIs(err, ErrDecoding)
+	}
+}
+
+func TestDecodePolynomial(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	testCases := []testCase{
+		{NewSlowEvaluator(f), 18, 5},
+		{NewNttEvaluator(f), 16, 4},
+	}
+
+	for _, tc := range testCases {
+		prms, err := NewCodeParameters(tc.EvaluationMap, tc.n, tc.k)
+		a.NoError(err)
+
+		gao := NewCodeGao(prms)
+		pr := field.NewDensePolyRing(f)
+
+		encoded, err := gao.Encode(makeTestSlice(tc.k))
+		a.NoError(err)
+
+		for i, x := range prms.EvaluationPoints(tc.n)[:prms.MaxErrors()] {
+			encoded[x] = f.Add(encoded[x], uint64(i+1))
+		}
+
+		p, err := gao.DecodePolynomial(encoded)
+		a.NoError(err)
+		a.Less(p.Degree(), tc.k)
+
+		decoded, err := gao.Decode(encoded)
+		a.NoError(err)
+		a.Equal(decoded, p.ToSlice())
+
+		// usable in further ring operations.
+		sq := &field.Polynomial{}
+		pr.MulPoly(p, p, sq)
+
+		for _, x := range prms.EvaluationPoints(tc.n)[prms.MaxErrors():] { // the uncorrupted points.
+			a.Equal(encoded[x], pr.Evaluate(p, x))
+			a.Equal(f.Mul(encoded[x], encoded[x]), pr.Evaluate(sq, x))
+		}
+
+		// the zero codeword.
+		zero, err := gao.Encode(nil)
+		a.NoError(err)
+
+		p, err = gao.DecodePolynomial(zero)
+		a.NoError(err)
+		a.True(p.IsZero())
+
+		_, err = gao.DecodePolynomial(map[uint64]uint64{})
+		a.ErrorIs(err, ErrTooManyMissingPoints)
+	}
+}
+
+func TestVariableMessageLength(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	testCases := []testCase{
+		{NewSlowEvaluator(f), 18, 5},
+		{NewNttEvaluator(f), 16, 4},
+	}
+
+	for _, tc := range testCases {
+		prms, err := NewCodeParameters(tc.EvaluationMap, tc.n, tc.k)
+		a.NoError(err)
+
+		gao := NewCodeGao(prms)
+
+		for _, k := range []int{2, tc.n / 2} {
+			data := makeTestSlice(k)
+
+			encoded, err := gao.EncodeVariable(data, k)
+			a.NoError(err)
+			a.Len(encoded, tc.n)
+
+			// (n-k)/2 errors, which differs from MaxErrors.
+			maxErrors := (tc.n - k) / 2
+			for i, x := range prms.EvaluationPoints(tc.n)[:maxErrors] {
+				encoded[x] = f.Add(encoded[x], uint64(i+1))
+			}
+
+			decoded, err := gao.DecodeVariable(encoded, k)
+			a.NoError(err, "k=%d", k)
+			a.Equal(data, decoded)
+			a.Equal(maxErrors, gao.LastDecodeErrors())
+
+			_, err = gao.EncodeVariable(makeTestSlice(k+1), k)
+			a.ErrorIs(err, ErrDataTooLarge)
+		}
+
+		// the fixed-rate API is unaffected.
+		a.Equal(tc.k, gao.K())
+
+		encoded, err := gao.Encode(makeTestSlice(tc.k))
+		a.NoError(err)
+
+		decoded, err := gao.Decode(encoded)
+		a.NoError(err)
+		a.Equal(makeTestSlice(tc.k), decoded)
+
+		for _, k := range []int{0, tc.n + 1} {
+			_, err = gao.EncodeVariable(nil, k)
+			a.ErrorIs(err, ErrInvalidMessageLength)
+
+			_, err = gao.DecodeVariable(encoded, k)
+			a.ErrorIs(err, ErrInvalidMessageLength)
+		}
+	}
+}
+
+func TestDecodeBestEffort(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	testCases := []testCase{
+		{NewSlowEvaluator(f), 18, 5},
+		{NewNttEvaluator(f), 16, 4},
+	}
+
+	for _, tc := range testCases {
+		prms, err := NewCodeParameters(tc.EvaluationMap, tc.n, tc.k)
+		a.NoError(err)
+
+		gao := NewCodeGao(prms)
+		data := makeTestSlice(tc.k)
+
+		encoded, err := gao.Encode(data)
+		a.NoError(err)
+
+		// within the unique decoding radius.
+		for i, x := range prms.EvaluationPoints(tc.n)[:prms.MaxErrors()] {
+			encoded[x] = f.Add(encoded[x], uint64(i+1))
+		}
+
+		msg, exact, err := gao.DecodeBestEffort(encoded)
+		a.NoError(err)
+		a.True(exact)
+		a.Equal(data, msg)
+
+		// adding x^(n-1) + 7x^(n-2) corrupts every symbol, so unique decoding fails, but it leaves the
+		// k lowest coefficients of the interpolant intact.
+		encoded, err = gao.Encode(data)
+		a.NoError(err)
+
+		for x, y := range encoded {
+			xn2 := f.Pow(x, uint64(tc.n-2))
+			encoded[x] = f.Add(y, f.Add(f.Mul(xn2, x), f.Mul(xn2, 7)))
+		}
+
+		received := maps.Clone(encoded)
+
+		_, err = gao.Decode(encoded)
+		a.ErrorIs(err, ErrDecoding)
+
+		msg, exact, err = gao.DecodeBestEffort(encoded)
+		a.NoError(err)
+		a.False(exact)
+		a.Equal(data, msg)
+		a.Equal(received, encoded) // not modified.
+
+		// other errors are returned as they are.
+		for _, x := range prms.EvaluationPoints(tc.n)[:prms.MaxErrors()+1] {
+			delete(encoded, x)
+		}
+
+		_, _, err = gao.DecodeBestEffort(encoded)
+		a.ErrorIs(err, ErrTooManyMissingPoints)
+	}
+}
+
+func TestDecodeVerified(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	testCases := []testCase{
+		{NewSlowEvaluator(f), 18, 5},
+		{NewNttEvaluator(f), 16, 4},
+	}
+
+	for _, tc := range testCases {
+		prms, err := NewCodeParameters(tc.EvaluationMap, tc.n, tc.k)
+		a.NoError(err)
+
+		gao := NewCodeGao(prms)
+		data := makeTestSlice(tc.k)
+
+		encoded, err := gao.Encode(data)
+		a.NoError(err)
+
+		// an erasure and MaxErrors()-1 errors.
+		xs := prms.EvaluationPoints(tc.n)
+		delete(encoded, xs[0])
+		for i, x := range xs[1:prms.MaxErrors()] {
+			encoded[x] = f.Add(encoded[x], uint64(i+1))
+		}
+
+		received := maps.Clone(encoded)
+
+		msg, err := gao.DecodeVerified(encoded)
+		a.NoError(err)
+		a.Equal(data, msg)
+		a.Equal(received, encoded) // not modified.
+
+		// a tampered decode re-encodes far from the received word.
+		_, ys, present, err := gao.prepareDecoding(encoded)
+		a.NoError(err)
+		a.NoError(gao.verify(data, ys, present))
+
+		tampered := slices.Clone(data)
+		tampered[0] = f.Add(tampered[0], 1)
+		a.ErrorIs(gao.verify(tampered, ys, present), ErrVerificationFailed)
+	}
+}
+
+func TestCountErrors(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	testCases := []testCase{
+		{NewSlowEvaluator(f), 18, 5},
+		{NewNttEvaluator(f), 16, 4},
+	}
+
+	for _, tc := range testCases {
+		prms, err := NewCodeParameters(tc.EvaluationMap, tc.n, tc.k)
+		a.NoError(err)
+
+		gao := NewCodeGao(prms)
+		data := makeTestSlice(tc.k)
+
+		encoded, err := gao.Encode(data)
+		a.NoError(err)
+
+		numErrors, err := gao.CountErrors(encoded, data)
+		a.NoError(err)
+		a.Zero(numErrors)
+
+		// 2 erasures and MaxErrors()-2 errors.
+		shuffledXs := shuffle(prms.EvaluationPoints(tc.n))
+		for _, x := range shuffledXs[:2] {
+			delete(encoded, x)
+		}
+
+		for i, x := range shuffledXs[2:prms.MaxErrors()] {
+			encoded[x] = f.Add(encoded[x], uint64(i+1))
+		}
+
+		decoded, err := gao.Decode(encoded)
+		a.NoError(err)
+
+		numErrors, err = gao.CountErrors(encoded, decoded)
+		a.NoError(err)
+		a.Equal(prms.MaxErrors()-2, numErrors)
+
+		// unreduced but equal values aren't errors.
+		x := shuffledXs[len(shuffledXs)-1]
+		encoded[x] += f.Modulus()
+
+		numErrors, err = gao.CountErrors(encoded, decoded)
+		a.NoError(err)
+		a.Equal(prms.MaxErrors()-2, numErrors)
+
+		_, err = gao.CountErrors(encoded, makeTestSlice(tc.k+1))
+		a.ErrorIs(err, ErrDataTooLarge)
+	}
+}
+
+func TestDecodeArbitrary(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	bf, err := field.NewBinaryField(8)
+	a.NoError(err)
+
+	testCases := []testCase{
+		{NewSlowEvaluator(f), 18, 5},
+		{NewNttEvaluator(f), 16, 4},
+		{NewSlowEvaluator(bf), 30, 10},
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+
+	for _, tc := range testCases {
+		prms, err := NewCodeParameters(tc.EvaluationMap, tc.n, tc.k)
+		a.NoError(err)
+
+		gao := NewCodeGao(prms)
+		fld := prms.PrimeField()
+		pr := field.NewDensePolyRing(fld)
+
+		data := makeTestSlice(tc.k)
+		msg := field.NewPolynomial(fld, data, false)
+
+		// m distinct random x-values, unrelated to the evaluator's domain.
+		const m = 15
+		points := make(map[uint64]uint64, m)
+		xs := make([]uint64, 0, m)
+		for len(xs) < m {
+			x := rnd.Uint64() % fld.Modulus()
+			if _, ok := points[x]; !ok {
+				points[x] = pr.EvaluateAny(msg, x)
+				xs = append(xs, x)
+			}
+		}
+
+		decoded, err := gao.DecodeArbitrary(points)
+		a.NoError(err)
+		a.Equal(data, decoded)
+		a.Equal(0, gao.LastDecodeErrors())
+
+		// corrupt (m-k)/2 of them.
+		numErrors := (m - tc.k) / 2
+		received := maps.Clone(points)
+		for _, x := range xs[:numErrors] {
+			received[x] = fld.Add(received[x], 1)
+		}
+
+		decoded, err = gao.DecodeArbitrary(received)
+		a.NoError(err)
+		a.Equal(data, decoded)
+		a.Equal(numErrors, gao.LastDecodeErrors())
+
+		// the codeword itself decodes too, with its keys in any order.
+		encoded, err := gao.Encode(data)
+		a.NoError(err)
+
+		decoded, err = gao.DecodeArbitrary(encoded)
+		a.NoError(err)
+		a.Equal(data, decoded)
+
+		// too few points, and keys that aren't field elements.
+		few := make(map[uint64]uint64)
+		for _, x := range xs[:tc.k-1] {
+			few[x] = points[x]
+		}
+
+		_, err = gao.DecodeArbitrary(few)
+		a.ErrorIs(err, ErrTooManyMissingPoints)
+
+		received[fld.Modulus()] = 0
+		_, err = gao.DecodeArbitrary(received)
+		a.ErrorIs(err, ErrInvalidPoint)
+	}
+}
+
+func TestExtendParity(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	testCases := []testCase{
+		{NewSlowEvaluator(f), 18, 10},
+		{NewNttEvaluator(f), 16, 8},
+	}
+
+	for _, tc := range testCases {
+		prms, err := NewCodeParameters(tc.EvaluationMap, tc.n, tc.k)
+		a.NoError(err)
+
+		gao := NewCodeGao(prms)
+		data := makeTestSlice(tc.k)
+
+		encoded, err := gao.Encode(data)
+		a.NoError(err)
+
+		// 0 is never an evaluation point of these codes.
+		extraPoints := []uint64{0, 50000, 50001, 50002, 50003, 50004, 50005, 50006}
+
+		extended, err := gao.ExtendParity(encoded, extraPoints)
+		a.NoError(err)
+		a.Len(extended, tc.n+len(extraPoints))
+		a.Len(encoded, tc.n) // not modified.
+
+		// the extended codeword corrects 4 more errors than MaxErrors.
+		numErrors := prms.MaxErrors() + len(extraPoints)/2
+		for i, x := range prms.EvaluationPoints(tc.n)[:numErrors] {
+			extended[x] = f.Add(extended[x], uint64(i+1))
+		}
+
+		decoded, err := gao.DecodeArbitrary(extended)
+		a.NoError(err)
+		a.Equal(data, decoded)
+		a.Equal(numErrors, gao.LastDecodeErrors())
+
+		// corrupted or incomplete codewords, and taken points.
+		_, err = gao.ExtendParity(extended, nil)
+		a.ErrorIs(err, ErrTooManyPoints)
+
+		corrupted := copyPoints(encoded)
+		x := prms.EvaluationPoints(tc.n)[0]
+		corrupted[x] = f.Add(corrupted[x], 1)
+
+		_, err = gao.ExtendParity(corrupted, extraPoints)
+		a.ErrorIs(err, ErrNotACodeword)
+
+		delete(corrupted, x)
+		_, err = gao.ExtendParity(corrupted, extraPoints)
+		a.ErrorIs(err, ErrIncompleteCodeword)
+
+		_, err = gao.ExtendParity(encoded, []uint64{0, 0})
+		a.ErrorIs(err, ErrDuplicatePoint)
+
+		_, err = gao.ExtendParity(encoded, []uint64{x})
+		a.ErrorIs(err, ErrDuplicatePoint)
+
+		_, err = gao.ExtendParity(encoded, []uint64{f.Modulus()})
+		a.ErrorIs(err, ErrInvalidPoint)
+	}
+
+	// extending with the next evaluation points yields the codeword of a longer code.
+	prms, err := NewCodeParameters(NewSlowEvaluator(f), 18, 10)
+	a.NoError(err)
+
+	longer, err := NewCodeParameters(NewSlowEvaluator(f), 26, 10)
+	a.NoError(err)
+
+	encoded, err := NewCodeGao(prms).Encode(makeTestSlice(10))
+	a.NoError(err)
+
+	extended, err := NewCodeGao(prms).ExtendParity(encoded, longer.EvaluationPoints(26)[18:])
+	a.NoError(err)
+
+	expected, err := NewCodeGao(longer).Encode(makeTestSlice(10))
+	a.NoError(err)
+	a.Equal(expected, extended)
+}
+
+// budgetContext is done after its Err method is called budget times.
+type budgetContext struct {
+	context.Context
+	budget, calls int
+}
+
+func (c *budgetContext) Err() error {
+	c.calls++
+	if c.calls > c.budget {
+		return context.Canceled
+	}
+
+	return nil
+}
+
+func TestDecodeContext(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	testCases := []testCase{
+		{NewSlowEvaluator(f), 64, 20},
+		{NewNttEvaluator(f), 1 << 10, 1 << 9},
+	}
+
+	for _, tc := range testCases {
+		prms, err := NewCodeParameters(tc.EvaluationMap, tc.n, tc.k)
+		a.NoError(err)
+
+		gao := NewCodeGao(prms)
+		data := makeTestSlice(tc.k)
+
+		encoded, err := gao.Encode(data)
+		a.NoError(err)
+
+		for i, x := range prms.EvaluationPoints(tc.n)[:prms.MaxErrors()] {
+			encoded[x] = f.Add(encoded[x], uint64(i+1))
+		}
+
+		decoded, err := gao.DecodeContext(context.Background(), encoded)
+		a.NoError(err)
+		a.Equal(data, decoded)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err = gao.DecodeContext(ctx, encoded)
+		a.ErrorIs(err, context.Canceled)
+
+		// cancelled in the middle of the partial EEA: after the checks before and after the interpolation,
+		// and the first EEA step.
+		tracer := &recordingTracer{}
+		gao.SetTracer(tracer)
+
+		budgetCtx := &budgetContext{Context: context.Background(), budget: 3}
+
+		_, err = gao.DecodeContext(budgetCtx, encoded)
+		a.ErrorIs(err, context.Canceled)
+		a.Equal(4, budgetCtx.calls)
+		a.Equal([]string{"interpolate"}, tracer.calls)
+
+		gao.SetTracer(nil)
+
+		// an expired deadline.
+		ctx, cancel = context.WithTimeout(context.Background(), time.Microsecond)
+		defer cancel()
+
+		<-ctx.Done()
+
+		_, err = gao.DecodeContext(ctx, encoded)
+		a.ErrorIs(err, context.DeadlineExceeded)
+	}
+}
+
+func TestLocatorDerivativeEval(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	bf, err := field.NewBinaryField(8)
+	a.NoError(err)
+
+	coset, err := NewCosetNttEvaluator(f, f.Generator())
+	a.NoError(err)
+
+	testCases := []testCase{
+		{NewSlowEvaluator(f), 18, 5},
+		{NewNttEvaluator(f), 16, 4}, // sparse g0.
+		{coset, 16, 4},
+		{NewSlowEvaluator(bf), 30, 10},
+	}
+
+	for _, tc := range testCases {
+		prms, err := NewCodeParameters(tc.EvaluationMap, tc.n, tc.k)
+		a.NoError(err)
+
+		gao := NewCodeGao(prms)
+		fld := prms.PrimeField()
+		pr := field.NewDensePolyRing(fld)
+		g0 := tc.GenerateLocatorPolynomial(tc.n)
+		xs := prms.EvaluationPoints(tc.n)
+
+		for i, xi := range xs {
+			deriv := gao.LocatorDerivativeEval(xi)
+
+			// g0 = (x - x_i) * q, thus g0'(x_i) = q(x_i).
+			q, r := pr.LongDiv(g0, field.NewPolynomial(fld, []uint64{fld.Neg(xi), 1}, false))
+			a.True(r.IsZero())
+			a.Equal(pr.Evaluate(q, xi), deriv)
+
+			// g0'(x_i) = c * \prod_{j != i} (x_i - x_j), for g0's leading coefficient c.
+			prod := g0.LeadCoeff()
+			for j, xj := range xs {
+				if j != i {
+					prod = fld.Mul(prod, fld.Sub(xi, xj))
+				}
+			}
+
+			a.Equal(prod, deriv)
+			a.NotZero(deriv)
+		}
+
+		// off the evaluation points too.
+		deriv := &field.Polynomial{}
+		pr.Derivative(g0, deriv)
+		a.Equal(pr.Evaluate(deriv, 12345%fld.Modulus()), gao.LocatorDerivativeEval(12345%fld.Modulus()))
+	}
+}
+
+func TestErrorEvaluator(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	bf, err := field.NewBinaryField(8)
+	a.NoError(err)
+
+	coset, err := NewCosetNttEvaluator(f, f.Generator())
+	a.NoError(err)
+
+	testCases := []testCase{
+		{NewSlowEvaluator(f), 18, 5},
+		{NewNttEvaluator(f), 16, 4},
+		{coset, 16, 4},
+		{NewSlowEvaluator(bf), 30, 10},
+	}
+
+	for _, tc := range testCases {
+		prms, err := NewCodeParameters(tc.EvaluationMap, tc.n, tc.k)
+		a.NoError(err)
+
+		gao := NewCodeGao(prms)
+		fld := prms.PrimeField()
+		pr := field.NewDensePolyRing(fld)
+
+		for _, data := range [][]uint64{makeTestSlice(tc.k), make([]uint64, tc.k)} {
+			encoded, err := gao.Encode(data)
+			a.NoError(err)
+
+			omega, err := gao.ErrorEvaluator(encoded)
+			a.NoError(err)
+			a.True(omega.IsZero())
+
+			// an erasure, whose magnitude is minus the symbol, and MaxErrors()-1 errors.
+			magnitudes := map[uint64]uint64{}
+			shuffledXs := shuffle(prms.EvaluationPoints(tc.n))
+
+			magnitudes[shuffledXs[0]] = fld.Neg(encoded[shuffledXs[0]])
+			delete(encoded, shuffledXs[0])
+
+			for i, x := range shuffledXs[1:prms.MaxErrors()] {
+				magnitudes[x] = uint64(i + 1)
+				encoded[x] = fld.Add(encoded[x], magnitudes[x])
+			}
+
+			omega, err = gao.ErrorEvaluator(encoded)
+			a.NoError(err)
+
+			errXs := make([]uint64, 0, len(magnitudes))
+			for x, e := range magnitudes {
+				if e != 0 {
+					errXs = append(errXs, x)
+				}
+			}
+
+			lambda := field.VanishingPolynomial(pr, errXs)
+			a.Less(omega.Degree(), lambda.Degree())
+
+			deriv := &field.Polynomial{}
+			pr.Derivative(lambda, deriv)
+
+			for _, x := range errXs {
+				a.Equal(magnitudes[x], fld.Mul(pr.Evaluate(omega, x), fld.Inverse(pr.Evaluate(deriv, x))))
+			}
+		}
+
+		_, err = gao.ErrorEvaluator(map[uint64]uint64{})
+		a.ErrorIs(err, ErrTooManyMissingPoints)
+
+		// x^k divides exactly, yet isn't a message.
+		xk := map[uint64]uint64{}
+		for _, x := range prms.EvaluationPoints(tc.n) {
+			xk[x] = fld.Pow(x, uint64(tc.k))
+		}
+
+		_, err = gao.ErrorEvaluator(xk)
+		a.ErrorIs(err, ErrDecoding)
+	}
+}
+
+func TestOrderedEncoding(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	testCases := []testCase{
+		{NewSlowEvaluator(f), 18, 5},
+		{NewNttEvaluator(f), 16, 4},
+	}
+
+	for _, tc := range testCases {
+		prms, err := NewCodeParameters(tc.EvaluationMap, tc.n, tc.k)
+		a.NoError(err)
+
+		gao := NewCodeGao(prms)
+
+		encoded, err := gao.Encode(makeTestSlice(tc.k))
+		a.NoError(err)
+
+		codeword, err := gao.EncodeOrdered(makeTestSlice(tc.k))
+		a.NoError(err)
+		a.Len(codeword, prms.N())
+
+		// cross-check against the map-based API.
+		for i, x := range prms.EvaluationPoints(prms.N()) {
+			a.Equal(encoded[x], codeword[i])
+		}
+
+		decoded, err := gao.DecodeOrdered(codeword, nil)
+		a.NoError(err)
+		a.Equal(makeTestSlice(tc.k), decoded)
+
+		// erasures and corruptions.
+		present := make([]bool, prms.N())
+		for i := range present {
+			present[i] = true
+		}
+
+		positions := rand.Perm(prms.N())
+		numErasures := prms.MaxErrors() / 2
+		for _, pos := range positions[:numErasures] {
+			present[pos] = false
+			codeword[pos] = 0
+		}
+
+		// erasures are filled with zeros, thus they count towards MaxErrors.
+		for _, pos := range positions[numErasures:prms.MaxErrors()] {
+			codeword[pos] = f.Add(codeword[pos], 1)
+		}
+
+		decoded, err = gao.DecodeOrdered(codeword, present)
+		a.NoError(err)
+		a.Equal(makeTestSlice(tc.k), decoded)
+
+		_, err = gao.DecodeOrdered(codeword[1:], nil)
+		a.ErrorIs(err, ErrCodewordSizeMismatch)
+
+		for i := range present {
+			present[i] = i > prms.MaxErrors()
+		}
+
+		_, err = gao.DecodeOrdered(codeword, present)
+		a.ErrorIs(err, ErrTooManyMissingPoints)
+	}
+}
+
+func TestZeroSymbolIsNotErasure(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	testCases := []testCase{
+		{NewSlowEvaluator(f), 18, 5},
+		{NewNttEvaluator(f), 16, 4},
+	}
+
+	for _, tc := range testCases {
+		prms, err := NewCodeParameters(tc.EvaluationMap, tc.n, tc.k)
+		a.NoError(err)
+
+		gao := NewCodeGao(prms)
+		xs := prms.EvaluationPoints(prms.N())
+
+		// f(x) = x - x_0 vanishes on x_0.
+		data := []uint64{f.Neg(xs[0]), 1}
+		encoded, err := gao.Encode(data)
+		a.NoError(err)
+		a.Zero(encoded[xs[0]])
+
+		decoded, err := gao.Decode(encoded)
+		a.NoError(err)
+		a.Equal(data, decoded)
+		a.Equal(0, gao.LastDecodeErrors()) // the genuine 0 isn't an erasure.
+
+		for _, erased := range []uint64{xs[0], xs[1]} {
+			received := copyPoints(encoded)
+			delete(received, erased)
+
+			// received isn't modified, so decoding it again sees the same erasure.
+			for i := 0; i < 2; i++ {
+				decoded, err = gao.Decode(received)
+				a.NoError(err)
+				a.Equal(data, decoded)
+				a.Equal(1, gao.LastDecodeErrors())
+				a.Len(received, prms.N()-1)
+			}
+		}
+
+		// erasing the zero symbol, and an error.
+		received := copyPoints(encoded)
+		delete(received, xs[0])
+		received[xs[2]] = f.Add(received[xs[2]], 1)
+
+		decoded, err = gao.Decode(received)
+		a.NoError(err)
+		a.Equal(data, decoded)
+		a.Equal(2, gao.LastDecodeErrors())
+	}
+}
+
+func TestDecodeNearZeroCodeword(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	testCases := []testCase{
+		{NewSlowEvaluator(f), 18, 5},
+		{NewNttEvaluator(f), 16, 4},
+	}
+
+	for _, tc := range testCases {
+		prms, err := NewCodeParameters(tc.EvaluationMap, tc.n, tc.k)
+		a.NoError(err)
+
+		gao := NewCodeGao(prms)
+
+		codeword, err := gao.EncodeOrdered([]uint64{0})
+		a.NoError(err)
+
+		decoded, err := gao.DecodeOrdered(codeword, nil)
+		a.NoError(err)
+		a.Equal([]uint64{0}, decoded)
+
+		// g1 vanishes on n-MaxErrors points, thus shares a large factor with g0.
+		for _, pos := range rand.Perm(prms.N())[:prms.MaxErrors()] {
+			codeword[pos] = uint64(pos + 1)
+		}
+
+		decoded, err = gao.DecodeOrdered(codeword, nil)
+		a.NoError(err)
+		a.Equal([]uint64{0}, decoded)
+
+		// erasures of the zero codeword.
+		encoded, err := gao.Encode(nil)
+		a.NoError(err)
+
+		for _, x := range shuffle(prms.EvaluationPoints(prms.N()))[:prms.MaxErrors()] {
+			delete(encoded, x)
+		}
+
+		decoded, err = gao.Decode(encoded)
+		a.NoError(err)
+		a.Equal([]uint64{0}, decoded)
+	}
+}
+
+func TestNttCodeAnyLength(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	coset, err := NewCosetNttEvaluator(f, f.Generator())
+	a.NoError(err)
+
+	for _, ev := range []EvaluationMap{NewNttEvaluator(f), coset} {
+		for _, n := range []int{16, 18, 27} {
+			prms, err := NewCodeParameters(ev, n, 5)
+			a.NoError(err)
+
+			gao := NewCodeGao(prms)
+
+			codeword, err := gao.EncodeOrdered(makeTestSlice(5))
+			a.NoError(err)
+			a.Len(codeword, n)
+
+			for _, pos := range rand.Perm(n)[:prms.MaxErrors()] {
+				codeword[pos] = f.Add(codeword[pos], 1)
+			}
+
+			decoded, err := gao.DecodeOrdered(codeword, nil)
+			a.NoError(err, "%T n=%d", ev, n)
+			a.Equal(makeTestSlice(5), decoded)
+
+			// only a full subgroup (or its coset) decodes on the NTT path.
+			if field.IsPowerOfTwo(uint64(n)) {
+				a.Equal("ntt", gao.LastDecodePath())
+			} else {
+				a.Equal("generic", gao.LastDecodePath())
+			}
+		}
+	}
+}
+
+func TestCodeParametersDomainTooSmall(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(157)
+	a.NoError(err)
+
+	// 1..200 would wrap around the field, colliding with each other and with 0.
+	_, err = NewCodeParameters(NewSlowEvaluator(f), 200, 10)
+	a.ErrorIs(err, ErrDomainTooSmall)
+
+	_, err = NewCodeParameters(NewSlowEvaluator(f), 157, 10)
+	a.ErrorIs(err, ErrDomainTooSmall)
+
+	_, err = NewCodeParameters(NewSlowEvaluator(f), 156, 10)
+	a.NoError(err)
+
+	// 156 = 4 * 39 has no roots of unity of order 8.
+	_, err = NewCodeParameters(NewNttEvaluator(f), 8, 2)
+	a.ErrorIs(err, ErrNoRootOfUnity)
+
+	_, err = NewCodeParameters(NewNttEvaluator(f), 4, 2)
+	a.NoError(err)
+}
+
+func TestNttEvaluatorPadding(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	ev := NewNttEvaluator(f)
+	slow := NewSlowEvaluator(f)
+
+	// length 5 is padded to 8, and truncated back to the first 5 points.
+	p := field.NewPolynomial(f, makeTestSlice(5), false)
+	ys, err := ev.EvaluatePolynomial(p)
+	a.NoError(err)
+	a.Len(ys, 5)
+	a.Equal(makeTestSlice(5), p.ToSlice()) // padded into a new polynomial.
+
+	for i, x := range ev.EvaluationPoints(5) {
+		a.Equal(slow.pr.Evaluate(p, x), ys[i])
+	}
+
+	prms, err := NewCodeParameters(ev, 16, 5)
+	a.NoError(err)
+
+	gao := NewCodeGao(prms)
+
+	codeword, err := gao.EncodeOrdered(makeTestSlice(5))
+	a.NoError(err)
+
+	for _, pos := range rand.Perm(prms.N())[:prms.MaxErrors()] {
+		codeword[pos] = f.Add(codeword[pos], 1)
+	}
+
+	decoded, err := gao.DecodeOrdered(codeword, nil)
+	a.NoError(err)
+	a.Equal(makeTestSlice(5), decoded)
+}
+
+func TestIsValidCodeword(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	testCases := []testCase{
+		{NewSlowEvaluator(f), 18, 5},
+		{NewNttEvaluator(f), 16, 4},
+	}
+
+	for _, tc := range testCases {
+		prms, err := NewCodeParameters(tc.EvaluationMap, tc.n, tc.k)
+		a.NoError(err)
+
+		gao := NewCodeGao(prms)
+
+		encoded, err := gao.Encode(makeTestSlice(tc.k))
+		a.NoError(err)
+
+		valid, err := gao.IsValidCodeword(encoded)
+		a.NoError(err)
+		a.True(valid)
+
+		x := prms.EvaluationPoints(prms.N())[3]
+		encoded[x] = f.Add(encoded[x], 1)
+
+		valid, err = gao.IsValidCodeword(encoded)
+		a.NoError(err)
+		a.False(valid)
+
+		delete(encoded, x)
+
+		_, err = gao.IsValidCodeword(encoded)
+		a.ErrorIs(err, ErrIncompleteCodeword)
+		a.Len(encoded, prms.N()-1) // not modified.
+	}
+}
+
+func TestDistance(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	testCases := []testCase{
+		{NewSlowEvaluator(f), 18, 5},
+		{NewNttEvaluator(f), 16, 4},
+	}
+
+	for _, tc := range testCases {
+		prms, err := NewCodeParameters(tc.EvaluationMap, tc.n, tc.k)
+		a.NoError(err)
+
+		gao := NewCodeGao(prms)
+
+		encoded, err := gao.Encode(makeTestSlice(tc.k))
+		a.NoError(err)
+
+		d, err := gao.Distance(encoded, encoded)
+		a.NoError(err)
+		a.Zero(d)
+
+		corrupted := copyPoints(encoded)
+		positions := gaotest.CorruptN(f, corrupted, 5, rand.New(rand.NewSource(1)))
+
+		d, err = gao.Distance(encoded, corrupted)
+		a.NoError(err)
+		a.Equal(5, d)
+
+		// unreduced values are compared as field elements.
+		var x uint64
+		for _, x = range prms.EvaluationPoints(prms.N()) {
+			if !slices.Contains(positions, x) {
+				break
+			}
+		}
+
+		corrupted[x] = encoded[x] + f.Modulus()
+
+		d, err = gao.Distance(corrupted, encoded)
+		a.NoError(err)
+		a.Equal(5, d)
+
+		delete(corrupted, x)
+
+		_, err = gao.Distance(encoded, corrupted)
+		a.ErrorIs(err, ErrIncompleteCodeword)
+
+		corrupted[x], corrupted[0] = encoded[x], 0 // 0 is never an evaluation point.
+
+		_, err = gao.Distance(corrupted, encoded)
+		a.ErrorIs(err, ErrTooManyPoints)
+	}
+}
+
+func TestSyndromes(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	bf, err := field.NewBinaryField(8)
+	a.NoError(err)
+
+	testCases := []testCase{
+		{NewSlowEvaluator(f), 18, 5},
+		{NewSlowEvaluatorWithStrategy(f, GeneratorPowerPoints), 18, 5},
+		{NewNttEvaluator(f), 16, 4},
+		{NewSlowEvaluator(bf), 30, 10},
+	}
+
+	for _, tc := range testCases {
+		prms, err := NewCodeParameters(tc.EvaluationMap, tc.n, tc.k)
+		a.NoError(err)
+
+		gao := NewCodeGao(prms)
+		fld := prms.PrimeField()
+
+		encoded, err := gao.Encode(makeTestSlice(tc.k))
+		a.NoError(err)
+
+		syndromes, err := gao.Syndromes(encoded)
+		a.NoError(err)
+		a.Len(syndromes, tc.n-tc.k)
+		a.Equal(make([]uint64, tc.n-tc.k), syndromes)
+
+		x := prms.EvaluationPoints(prms.N())[3]
+		encoded[x] = fld.Add(encoded[x], 1)
+
+		syndromes, err = gao.Syndromes(encoded)
+		a.NoError(err)
+		a.NotEqual(make([]uint64, tc.n-tc.k), syndromes)
+
+		// a single error e at x_i gives S_j = w_i * e * x_i^j, thus S_1 = x_i * S_0.
+		a.NotZero(syndromes[0])
+		a.Equal(fld.Mul(x, syndromes[0]), syndromes[1])
+
+		delete(encoded, x)
+
+		_, err = gao.Syndromes(encoded)
+		a.ErrorIs(err, ErrIncompleteCodeword)
+	}
+}
+
+func TestEncodeExact(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	prms, err := NewCodeParameters(NewNttEvaluator(f), 16, 4)
+	a.NoError(err)
+
+	gao := NewCodeGao(prms)
+
+	// strict.
+	_, err = gao.EncodeExact(makeTestSlice(3))
+	a.ErrorIs(err, ErrDataLengthMismatch)
+
+	_, err = gao.EncodeExact(makeTestSlice(5))
+	a.ErrorIs(err, ErrDataLengthMismatch)
+
+	exact, err := gao.EncodeExact([]uint64{1, 2, 3, 0})
+	a.NoError(err)
+
+	// lenient: short data is zero-padded into the same codeword.
+	lenient, err := gao.EncodeOrdered(makeTestSlice(3))
+	a.NoError(err)
+	a.Equal(exact, lenient)
+
+	decoded, err := gao.DecodeOrdered(exact, nil)
+	a.NoError(err)
+	a.Equal(makeTestSlice(3), decoded)
+}
+
+func TestSymbolOverflowError(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	prms, err := NewCodeParameters(NewSlowEvaluator(f), 18, 5)
+	a.NoError(err)
+
+	gao := NewCodeGao(prms)
+
+	data := []uint64{1, 2, 3, 65537, 5}
+
+	_, err = gao.Encode(data)
+	a.ErrorIs(err, ErrDataElementsTooLarge)
+
+	var tooLarge *SymbolOverflowError
+	a.True(errors.As(err, &tooLarge))
+	a.Equal(3, tooLarge.Index)
+	a.Equal(uint64(65537), tooLarge.Value)
+	a.Equal(uint64(65537), tooLarge.Modulus)
+	a.EqualError(err, "data elements too large: symbol 3 is 65537, field modulus is 65537")
+
+	_, err = gao.EncodeOrdered(data)
+	a.ErrorIs(err, ErrDataElementsTooLarge)
+}
+
+func TestUpdateSymbol(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
 	testCases := []testCase{
 		{NewSlowEvaluator(f), 18, 5},
-		{NewNttEvaluator(f), 16, 4}, // checking non powers of 2.
+		{NewNttEvaluator(f), 16, 4},
 	}
 
 	for _, tc := range testCases {
+		prms, err := NewCodeParameters(tc.EvaluationMap, tc.n, tc.k)
+		a.NoError(err)
+
+		gao := NewCodeGao(prms)
+
+		data := makeTestSlice(tc.k)
+		codeword, err := gao.Encode(data)
+		a.NoError(err)
+
+		for _, upd := range []struct {
+			index  int
+			newVal uint64
+		}{{0, 100}, {tc.k - 1, 65536}, {1, 0}, {tc.k - 1, 7}} {
+			a.NoError(gao.UpdateSymbol(codeword, upd.index, data[upd.index], upd.newVal))
+			data[upd.index] = upd.newVal
+
+			expected, err := gao.Encode(data)
+			a.NoError(err)
+			a.Equal(expected, codeword)
+		}
+
+		// erased points stay erased.
+		x := prms.EvaluationPoints(prms.N())[2]
+		delete(codeword, x)
+
+		a.NoError(gao.UpdateSymbol(codeword, 2, data[2], 9))
+		data[2] = 9
+		a.NotContains(codeword, x)
+
+		decoded, err := gao.Decode(codeword)
+		a.NoError(err)
+		a.Equal(data, decoded)
+
+		a.ErrorIs(gao.UpdateSymbol(codeword, tc.k, 0, 1), ErrSymbolIndexOutOfRange)
+		a.ErrorIs(gao.UpdateSymbol(codeword, -1, 0, 1), ErrSymbolIndexOutOfRange)
+		a.ErrorIs(gao.UpdateSymbol(codeword, 0, 0, 65537), ErrDataElementsTooLarge)
+	}
+}
+
+func TestGeneratorMatrix(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	testCases := []testCase{
+		{NewSlowEvaluator(f), 18, 5},
+		{NewNttEvaluator(f), 16, 4},
+		{NewNttEvaluator(f), 16, 16},
+	}
 
+	for _, tc := range testCases {
 		prms, err := NewCodeParameters(tc.EvaluationMap, tc.n, tc.k)
 		a.NoError(err)
 
 		gao := NewCodeGao(prms)
 
-		encoded, err := gao.Encode(makeTestSlice(tc.k))
+		g := gao.GeneratorMatrix()
+		a.Len(g, tc.k)
+
+		for seed := range 5 {
+			data := make([]uint64, tc.k)
+			for i := range data {
+				data[i] = f.Reduce(uint64(seed*1000 + i*i + 7))
+			}
+
+			// data · G.
+			product := make([]uint64, tc.n)
+			for i, row := range g {
+				a.Len(row, tc.n)
+
+				for j, gij := range row {
+					product[j] = f.Add(product[j], f.Mul(data[i], gij))
+				}
+			}
+
+			expected, err := gao.EncodeOrdered(data)
+			a.NoError(err)
+			a.Equal(expected, product)
+		}
+
+		// the rows are copies.
+		g[tc.k-1][0] = f.Add(g[tc.k-1][0], 1)
+		a.NotEqual(g[tc.k-1], gao.GeneratorMatrix()[tc.k-1])
+	}
+}
+
+func TestLastDecodePath(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	testCases := []struct {
+		testCase
+		path string
+	}{
+		{testCase{NewSlowEvaluator(f), 18, 5}, "generic"},
+		{testCase{NewNttEvaluator(f), 16, 4}, "ntt"},
+	}
+
+	for _, tc := range testCases {
+		prms, err := NewCodeParameters(tc.EvaluationMap, tc.n, tc.k)
 		a.NoError(err)
 
-		// no corruptions
-		decoded, err := gao.Decode(encoded)
+		gao := NewCodeGao(prms)
+		a.Equal("", gao.LastDecodePath())
+		a.Equal(-1, gao.LastDecodeErrors())
+
+		codeword, err := gao.EncodeOrdered(makeTestSlice(tc.k))
 		a.NoError(err)
 
+		codeword[0] = f.Add(codeword[0], 1)
+		codeword[3] = f.Add(codeword[3], 1)
+
+		decoded, err := gao.DecodeOrdered(codeword, nil)
+		a.NoError(err)
 		a.Equal(makeTestSlice(tc.k), decoded)
-	}
 
+		a.Equal(tc.path, gao.LastDecodePath())
+		a.Equal(2, gao.LastDecodeErrors())
+
+		a.Zero(testing.AllocsPerRun(10, func() {
+			_ = gao.LastDecodePath()
+			_ = gao.LastDecodeErrors()
+		}))
+	}
 }
 
-func TestErasures(t *testing.T) {
+func TestDecodeRaw(t *testing.T) {
 	a := assert.New(t)
 	f, err := field.NewPrimeField(65537)
 	a.NoError(err)
 
 	testCases := []testCase{
 		{NewSlowEvaluator(f), 18, 5},
-		{NewNttEvaluator(f), 16, 4}, // checking non powers of 2.
+		{NewNttEvaluator(f), 16, 4},
 	}
 
 	for _, tc := range testCases {
@@ -72,42 +1658,104 @@ func TestErasures(t *testing.T) {
 		encoded, err := gao.Encode(makeTestSlice(tc.k))
 		a.NoError(err)
 
-		// add erasures
-		shuffledXs := shuffle(prms.EvaluationPoints(prms.n))
-		for i := 0; i < prms.MaxErrors(); i++ {
-			delete(encoded, shuffledXs[i])
+		// within budget: the raw outputs are what Decode accepts.
+		fPoly, r, err := gao.DecodeRaw(copyPoints(encoded))
+		a.NoError(err)
+		a.True(r.IsZero())
+		a.Equal(makeTestSlice(tc.k), fPoly.ToSlice())
+
+		// over budget.
+		xs := prms.EvaluationPoints(prms.N())
+		for i := 0; i <= prms.MaxErrors(); i++ {
+			encoded[xs[i]] = f.Add(encoded[xs[i]], uint64(i+1))
 		}
 
-		a.Greater(prms.N(), len(encoded))
+		_, err = gao.Decode(copyPoints(encoded))
+		a.ErrorIs(err, ErrDecoding)
 
-		decoded, err := gao.Decode(encoded)
+		fPoly, r, err = gao.DecodeRaw(copyPoints(encoded))
 		a.NoError(err)
+		a.True(!r.IsZero() || fPoly.Degree() > prms.K())
+	}
+}
 
-		a.Equal(makeTestSlice(tc.k), decoded)
+func copyPoints(points map[uint64]uint64) map[uint64]uint64 {
+	cpy := make(map[uint64]uint64, len(points))
+	for x, y := range points {
+		cpy[x] = y
 	}
+
+	return cpy
 }
 
-func shuffle(slc []uint64) []uint64 {
-	rnd := rand.New(rand.NewSource(time.Now().Unix()))
+func TestTranscode(t *testing.T) {
+	a := assert.New(t)
 
-	cpy := make([]uint64, len(slc))
-	copy(cpy, slc)
+	small, err := field.NewPrimeField(65537)
+	a.NoError(err)
 
-	rnd.Shuffle(len(slc), func(i, j int) {
-		cpy[i], cpy[j] = cpy[j], cpy[i]
-	})
+	large, err := field.NewPrimeField(9191248642791733759) // p > 2^62
+	a.NoError(err)
 
-	return cpy
+	smallPrms, err := NewCodeParameters(NewNttEvaluator(small), 16, 4)
+	a.NoError(err)
+
+	largePrms, err := NewCodeParameters(NewSlowEvaluator(large), 18, 4)
+	a.NoError(err)
+
+	smallCode, largeCode := NewCodeGao(smallPrms), NewCodeGao(largePrms)
+
+	encoded, err := smallCode.Encode(makeTestSlice(4))
+	a.NoError(err)
+
+	// corruptions are corrected along the way.
+	encoded[smallPrms.EvaluationPoints(16)[0]] += 1
+
+	migrated, err := smallCode.Transcode(encoded, largeCode)
+	a.NoError(err)
+	a.Len(migrated, 18)
+
+	decoded, err := largeCode.Decode(migrated)
+	a.NoError(err)
+	a.Equal(makeTestSlice(4), decoded)
+
+	// back to the small field: fine when the symbols fit.
+	back, err := largeCode.Transcode(migrated, smallCode)
+	a.NoError(err)
+
+	decoded, err = smallCode.Decode(back)
+	a.NoError(err)
+	a.Equal(makeTestSlice(4), decoded)
+
+	// symbols that don't fit the small field.
+	encoded, err = largeCode.Encode([]uint64{1, 1 << 40, 3})
+	a.NoError(err)
+
+	_, err = largeCode.Transcode(encoded, smallCode)
+	a.ErrorIs(err, ErrDataElementsTooLarge)
+
+	var overflow *SymbolOverflowError
+	a.True(errors.As(err, &overflow))
+	a.Equal(1, overflow.Index)
+	a.Equal(uint64(1<<40), overflow.Value)
+	a.Equal(uint64(65537), overflow.Modulus)
+
+	// k mismatch.
+	otherPrms, err := NewCodeParameters(NewSlowEvaluator(large), 18, 5)
+	a.NoError(err)
+
+	_, err = smallCode.Transcode(encoded, NewCodeGao(otherPrms))
+	a.ErrorIs(err, ErrKMismatch)
 }
 
-func TestCorruptions(t *testing.T) {
+func TestSetStopDegree(t *testing.T) {
 	a := assert.New(t)
 	f, err := field.NewPrimeField(65537)
 	a.NoError(err)
 
 	testCases := []testCase{
-		{NewSlowEvaluator(f), 18, 5},
-		{NewNttEvaluator(f), 16, 4}, // checking non powers of 2.
+		{NewSlowEvaluator(f), 32, 8},
+		{NewNttEvaluator(f), 32, 8},
 	}
 
 	for _, tc := range testCases {
@@ -116,27 +1764,140 @@ func TestCorruptions(t *testing.T) {
 
 		gao := NewCodeGao(prms)
 
-		encoded, err := gao.Encode(makeTestSlice(tc.k))
+		a.ErrorIs(gao.SetStopDegree(19), ErrInvalidStopDegree)
+		a.ErrorIs(gao.SetStopDegree(33), ErrInvalidStopDegree)
+
+		a.NoError(gao.SetStopDegree(20)) // the default.
+		a.Equal(12, gao.MaxErrors())
+
+		a.NoError(gao.SetStopDegree(28))
+		a.Equal(4, gao.MaxErrors())
+
+		codeword, err := gao.EncodeOrdered(makeTestSlice(tc.k))
 		a.NoError(err)
 
-		corrupted := make(map[uint64]uint64, len(encoded))
-		for x, y := range encoded {
-			corrupted[x] = y
+		corrupt := func(numErrors int) []uint64 {
+			corrupted := make([]uint64, len(codeword))
+			copy(corrupted, codeword)
+
+			for i := 0; i < numErrors; i++ {
+				corrupted[3*i] = f.Add(corrupted[3*i], uint64(i+1))
+			}
+
+			return corrupted
 		}
 
-		// add corruptions
-		shuffledXs := shuffle(prms.EvaluationPoints(prms.n))
-		for i := 0; i < prms.MaxErrors(); i++ {
-			corrupted[shuffledXs[i]] = rand.Uint64()
+		// within the reduced radius.
+		decoded, err := gao.DecodeOrdered(corrupt(4), nil)
+		a.NoError(err)
+		a.Equal(makeTestSlice(tc.k), decoded)
+		a.Equal(4, gao.LastDecodeErrors())
+
+		// the EEA stops before reaching the error locator of 6 errors.
+		_, err = gao.DecodeOrdered(corrupt(6), nil)
+		a.ErrorIs(err, ErrDecoding)
+
+		// erasures are bounded by the reduced radius too.
+		present := make([]bool, tc.n)
+		for i := 5; i < tc.n; i++ {
+			present[i] = true
 		}
 
-		a.Len(corrupted, prms.N())
-		a.NotEqual(encoded, corrupted)
+		_, err = gao.DecodeOrdered(codeword, present)
+		a.ErrorIs(err, ErrTooManyMissingPoints)
 
-		decoded, err := gao.Decode(corrupted)
-		a.NoError(err)
+		// while the default stop degree corrects them.
+		a.NoError(gao.SetStopDegree(20))
 
+		decoded, err = gao.DecodeOrdered(corrupt(6), nil)
+		a.NoError(err)
 		a.Equal(makeTestSlice(tc.k), decoded)
+
+		// the EEA takes fewer steps to reach the raised stop degree. With 10 errors, the remainders
+		// drop a degree per step until g, of degree k-1+10 = 17.
+		eeaSteps := func() int {
+			g1, err := gao.interpolate(gao.EvaluationPoints(tc.n), corrupt(10))
+			a.NoError(err)
+
+			steps := 0
+			gao.pr.PartialExtendedEuclideanTraced(gao.locator(), g1, gao.stopDegree, func(int, int, int, int) {
+				steps++
+			})
+
+			return steps
+		}
+
+		defaultSteps := eeaSteps()
+
+		a.NoError(gao.SetStopDegree(28))
+		a.Less(eeaSteps(), defaultSteps)
+	}
+}
+
+func TestLazyLocator(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	testCases := []struct {
+		testCase
+		sparse bool
+	}{
+		{testCase{NewSlowEvaluator(f), 18, 5}, false},
+		{testCase{NewNttEvaluator(f), 16, 4}, true},
+		{testCase{NewAutoEvaluator(f, 64), 64, 16}, true},
+		{testCase{NewAutoEvaluator(f, 18), 18, 5}, false},
+	}
+
+	for _, tc := range testCases {
+		prms, err := NewCodeParameters(tc.EvaluationMap, tc.n, tc.k)
+		a.NoError(err)
+
+		gao := NewCodeGao(prms)
+		a.Nil(gao.g0) // not computed yet.
+		a.Equal(tc.sparse, gao.g0Sparse != nil)
+
+		a.True(prms.GenerateLocatorPolynomial(tc.n).Equals(gao.locator()))
+		a.Equal(!tc.sparse, gao.g0 != nil)
+
+		codeword, err := gao.EncodeOrdered(makeTestSlice(tc.k))
+		a.NoError(err)
+
+		codeword[1] = f.Add(codeword[1], 1)
+
+		for _, code := range []*Code{gao, gao.Copy()} {
+			decoded, err := code.DecodeOrdered(codeword, nil)
+			a.NoError(err)
+			a.Equal(makeTestSlice(tc.k), decoded)
+		}
+	}
+}
+
+func TestSharedPolyRing(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	testCases := []testCase{
+		{NewSlowEvaluator(f), 18, 5},
+		{NewNttEvaluator(f), 16, 4},
+		{NewAutoEvaluator(f, 64), 64, 16},
+		{NewAutoEvaluator(f, 18), 18, 5},
+	}
+
+	for _, tc := range testCases {
+		prms, err := NewCodeParameters(tc.EvaluationMap, tc.n, tc.k)
+		a.NoError(err)
+
+		gao := NewCodeGao(prms)
+		cpy := gao.Copy()
+
+		// one ring, thus one twiddle cache, for the evaluator and all codes over it.
+		ring := tc.EvaluationMap.(polyRingMap).polyRing()
+		a.Same(ring, gao.pr)
+		a.Same(ring, cpy.pr)
+		a.Same(gao.interpolator, cpy.interpolator)
+		a.Same(ring, NewCodeGao(prms).pr)
 	}
 }
 
@@ -198,3 +1959,191 @@ func BenchmarkDecode(b *testing.B) {
 		}
 	}
 }
+
+/*
+Decoding MaxErrors errors with k = n/4, NTT overtakes SlowEvaluator from n = 32 on (medians of 3 runs):
+BenchmarkAutoNttThreshold/n=8/eval=slow         	   64255	     17805 ns/op
+BenchmarkAutoNttThreshold/n=8/eval=ntt          	   63830	     27022 ns/op
+BenchmarkAutoNttThreshold/n=16/eval=slow        	   24325	     47803 ns/op
+BenchmarkAutoNttThreshold/n=16/eval=ntt         	   23024	     54173 ns/op
+BenchmarkAutoNttThreshold/n=32/eval=slow        	    9968	    164032 ns/op
+BenchmarkAutoNttThreshold/n=32/eval=ntt         	   10000	    128012 ns/op
+BenchmarkAutoNttThreshold/n=64/eval=slow        	    2047	    555999 ns/op
+BenchmarkAutoNttThreshold/n=64/eval=ntt         	    3328	    338022 ns/op
+BenchmarkAutoNttThreshold/n=128/eval=slow       	     541	   2397863 ns/op
+BenchmarkAutoNttThreshold/n=128/eval=ntt        	     927	   1307498 ns/op
+*/
+func BenchmarkAutoNttThreshold(b *testing.B) {
+	f, err := field.NewPrimeField(65537)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for _, n := range []int{8, 16, 32, 64, 128} {
+		for _, ev := range []struct {
+			name string
+			eval EvaluationMap
+		}{
+			{"slow", NewSlowEvaluator(f)},
+			{"ntt", NewNttEvaluator(f)},
+		} {
+			prms, err := NewCodeParameters(ev.eval, n, n/4)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			gao := NewCodeGao(prms)
+
+			encoded, err := gao.Encode(makeTestSlice(n / 4))
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			gaotest.CorruptN(f, encoded, prms.MaxErrors(), rand.New(rand.NewSource(1)))
+
+			b.Run(fmt.Sprintf("n=%d/eval=%s", n, ev.name), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					if _, err := gao.Decode(encoded); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+/*
+BenchmarkDecodeNoCorruption/eval=slow/clean         	      40	  31324276 ns/op	   26728 B/op	       7 allocs/op
+BenchmarkDecodeNoCorruption/eval=slow/one-error     	      32	  33887467 ns/op	  461832 B/op	     603 allocs/op
+BenchmarkDecodeNoCorruption/eval=ntt/clean          	   13395	     91121 ns/op	   10344 B/op	       5 allocs/op
+BenchmarkDecodeNoCorruption/eval=ntt/one-error      	     301	   3825510 ns/op	  192774 B/op	     144 allocs/op
+*/
+func BenchmarkDecodeNoCorruption(b *testing.B) {
+	f, err := field.NewPrimeField(65537)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	const n, k = 1 << 10, 1 << 8
+
+	for _, ev := range []struct {
+		name string
+		eval EvaluationMap
+	}{
+		{"slow", NewSlowEvaluator(f)},
+		{"ntt", NewNttEvaluator(f)},
+	} {
+		prms, err := NewCodeParameters(ev.eval, n, k)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		gao := NewCodeGao(prms)
+
+		clean, err := gao.EncodeOrdered(makeTestSlice(k))
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		// a single error forces the full decoder.
+		corrupted := make([]uint64, n)
+		copy(corrupted, clean)
+		corrupted[0] = f.Add(corrupted[0], 1)
+
+		for _, cw := range []struct {
+			name     string
+			codeword []uint64
+		}{
+			{"clean", clean},
+			{"one-error", corrupted},
+		} {
+			b.Run(fmt.Sprintf("eval=%s/%s", ev.name, cw.name), func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					if _, err := gao.DecodeOrdered(cw.codeword, nil); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+/*
+BenchmarkSetStopDegree/stopDegree=640         	       9	 127327230 ns/op
+BenchmarkSetStopDegree/stopDegree=1008        	     152	   6608157 ns/op
+*/
+func BenchmarkSetStopDegree(b *testing.B) {
+	f, err := field.NewPrimeField(65537)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	const n, k = 1 << 10, 1 << 8
+
+	prms, err := NewCodeParameters(NewNttEvaluator(f), n, k)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	gao := NewCodeGao(prms)
+
+	// a random word is far from every codeword, so the EEA runs all the way to the stop degree.
+	rnd := rand.New(rand.NewSource(1))
+
+	word := make([]uint64, n)
+	for i := range word {
+		word[i] = f.Reduce(rnd.Uint64())
+	}
+
+	for _, d := range []int{(n + k) / 2, n - 16} {
+		b.Run(fmt.Sprintf("stopDegree=%d", d), func(b *testing.B) {
+			if err := gao.SetStopDegree(d); err != nil {
+				b.Fatal(err)
+			}
+
+			for i := 0; i < b.N; i++ {
+				if _, err := gao.DecodeOrdered(word, nil); !errors.Is(err, ErrDecoding) {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+/*
+Before g0 was lazy:
+BenchmarkNewCodeGao/eval=slow/n=4096         	      68	  17114516 ns/op	 1200271 B/op	   16462 allocs/op
+BenchmarkNewCodeGao/eval=ntt/n=4096          	  169623	      7043 ns/op	   41232 B/op	       6 allocs/op
+After:
+BenchmarkNewCodeGao/eval=slow/n=4096         	 4029194	       308.2 ns/op	     256 B/op	       4 allocs/op
+BenchmarkNewCodeGao/eval=ntt/n=4096          	 1601349	       748.9 ns/op	     456 B/op	      10 allocs/op
+*/
+func BenchmarkNewCodeGao(b *testing.B) {
+	f, err := field.NewPrimeField(65537)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	const n, k = 1 << 12, 1 << 10
+
+	for _, ev := range []struct {
+		name string
+		eval EvaluationMap
+	}{
+		{"slow", NewSlowEvaluator(f)},
+		{"ntt", NewNttEvaluator(f)},
+	} {
+		prms, err := NewCodeParameters(ev.eval, n, k)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(fmt.Sprintf("eval=%s/n=%d", ev.name, n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				NewCodeGao(prms)
+			}
+		})
+	}
+}
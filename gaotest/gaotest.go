@@ -0,0 +1,87 @@
+/*
+Package gaotest provides deterministic fault injection for property-testing Reed-Solomon codes built on go-gao.
+
+Codewords are the maps returned by Code.Encode (evaluation point -> value). Both helpers visit the points
+in ascending order before drawing from rng, thus the same codeword and seed always affect the same points.
+*/
+package gaotest
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"slices"
+
+	"github.com/jonathanmweiss/go-gao/field"
+)
+
+var errTooManyFaults = errors.New("cannot inject more faults than the codeword has symbols")
+
+var errNoRandomElement = errors.New("field doesn't draw random elements")
+
+// randomElementField is implemented by the fields of package field.
+type randomElementField interface {
+	RandomElement(src io.Reader) (uint64, error)
+}
+
+// CorruptN replaces the values of n distinct points of codeword with different, uniformly random elements of f,
+// drawn from rng with RandomElement, and returns the corrupted points in ascending order.
+// Panics if n is negative or exceeds len(codeword), or f has no RandomElement method.
+func CorruptN(f field.Field, codeword map[uint64]uint64, n int, rng *rand.Rand) []uint64 {
+	rf, ok := f.(randomElementField)
+	if !ok {
+		panic(errNoRandomElement)
+	}
+
+	xs := pickN(codeword, n, rng)
+
+	for _, x := range xs {
+		y := codeword[x]
+
+		v := y
+		for f.Equals(v, y) {
+			var err error
+			if v, err = rf.RandomElement(rng); err != nil {
+				panic(err) // rand.Rand reads never fail.
+			}
+		}
+
+		codeword[x] = v
+	}
+
+	return xs
+}
+
+// EraseN deletes n distinct points from codeword, and returns the erased points in ascending order.
+// Panics if n is negative or exceeds len(codeword).
+func EraseN(codeword map[uint64]uint64, n int, rng *rand.Rand) []uint64 {
+	xs := pickN(codeword, n, rng)
+
+	for _, x := range xs {
+		delete(codeword, x)
+	}
+
+	return xs
+}
+
+// pickN returns n distinct points of codeword, sorted.
+func pickN(codeword map[uint64]uint64, n int, rng *rand.Rand) []uint64 {
+	if n < 0 || n > len(codeword) {
+		panic(errTooManyFaults)
+	}
+
+	xs := make([]uint64, 0, len(codeword))
+	for x := range codeword {
+		xs = append(xs, x)
+	}
+
+	slices.Sort(xs)
+	rng.Shuffle(len(xs), func(i, j int) {
+		xs[i], xs[j] = xs[j], xs[i]
+	})
+
+	xs = xs[:n]
+	slices.Sort(xs)
+
+	return xs
+}
@@ -0,0 +1,126 @@
+package gaotest
+
+import (
+	"math/rand"
+	"testing"
+
+	gao "github.com/jonathanmweiss/go-gao"
+	"github.com/jonathanmweiss/go-gao/field"
+	"github.com/stretchr/testify/assert"
+)
+
+func encodeTestWord(a *assert.Assertions, f field.Field, n, k int) (*gao.Code, map[uint64]uint64, []uint64) {
+	prms, err := gao.NewCodeParameters(gao.NewSlowEvaluator(f), n, k)
+	a.NoError(err)
+
+	code := gao.NewCodeGao(prms)
+
+	data := make([]uint64, k)
+	for i := range data {
+		data[i] = uint64(i + 1)
+	}
+
+	encoded, err := code.Encode(data)
+	a.NoError(err)
+
+	return code, encoded, data
+}
+
+func copyWord(w map[uint64]uint64) map[uint64]uint64 {
+	cpy := make(map[uint64]uint64, len(w))
+	for x, y := range w {
+		cpy[x] = y
+	}
+
+	return cpy
+}
+
+func TestCorruptN(t *testing.T) {
+	a := assert.New(t)
+
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	code, encoded, data := encodeTestWord(a, f, 20, 6)
+
+	corrupted := copyWord(encoded)
+	xs := CorruptN(f, corrupted, 7, rand.New(rand.NewSource(1)))
+	a.Len(xs, 7)
+	a.IsIncreasing(xs)
+	a.Len(corrupted, len(encoded))
+
+	changed := 0
+	for x, y := range encoded {
+		if corrupted[x] != y {
+			changed++
+			a.Contains(xs, x)
+			a.Less(corrupted[x], f.Modulus())
+		}
+	}
+
+	a.Equal(7, changed)
+
+	decoded, err := code.Decode(corrupted)
+	a.NoError(err)
+	a.Equal(data, decoded)
+
+	// deterministic per seed.
+	again := copyWord(encoded)
+	a.Equal(xs, CorruptN(f, again, 7, rand.New(rand.NewSource(1))))
+	a.Equal(corrupted, again)
+
+	// values are drawn from the whole field, not only up to the codeword's largest value.
+	zeros := map[uint64]uint64{}
+	for x := uint64(1); x <= 100; x++ {
+		zeros[x] = 0
+	}
+
+	CorruptN(f, zeros, 100, rand.New(rand.NewSource(2)))
+
+	largest := uint64(0)
+	for _, y := range zeros {
+		a.NotZero(y)
+		largest = max(largest, y)
+	}
+
+	a.Greater(largest, f.Modulus()/2)
+
+	// unreduced values are compared as field elements, thus 4 isn't replaced by 1 in GF(3).
+	gf3, err := field.NewPrimeField(3)
+	a.NoError(err)
+
+	for seed := int64(0); seed < 20; seed++ {
+		unreduced := map[uint64]uint64{1: 4}
+		CorruptN(gf3, unreduced, 1, rand.New(rand.NewSource(seed)))
+		a.Contains([]uint64{0, 2}, unreduced[1])
+	}
+}
+
+func TestEraseN(t *testing.T) {
+	a := assert.New(t)
+
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	code, encoded, data := encodeTestWord(a, f, 20, 6)
+
+	erased := copyWord(encoded)
+	xs := EraseN(erased, 7, rand.New(rand.NewSource(3)))
+	a.Len(xs, 7)
+	a.IsIncreasing(xs)
+	a.Len(erased, 13)
+
+	for _, x := range xs {
+		a.NotContains(erased, x)
+	}
+
+	decoded, err := code.Decode(erased)
+	a.NoError(err)
+	a.Equal(data, decoded)
+
+	again := copyWord(encoded)
+	a.Equal(xs, EraseN(again, 7, rand.New(rand.NewSource(3))))
+
+	a.Panics(func() { EraseN(copyWord(encoded), 21, rand.New(rand.NewSource(3))) })
+	a.Panics(func() { CorruptN(f, copyWord(encoded), -1, rand.New(rand.NewSource(3))) })
+}
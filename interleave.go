@@ -0,0 +1,114 @@
+package gao
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrInvalidDepth         = errors.New("interleaving depth must be positive")
+	ErrMessageCountMismatch = errors.New("number of messages must equal the interleaving depth")
+)
+
+// InterleavedCoder interleaves depth codewords of a base Code symbol by symbol, thus a burst of b consecutive
+// corrupted symbols hits each codeword at most ceil(b/depth) times: bursts of up to depth*MaxErrors symbols
+// are corrected. Its buffers are reused across calls, so it isn't safe for concurrent use.
+type InterleavedCoder struct {
+	code  *Code
+	depth int
+
+	session *DecoderSession
+}
+
+func NewInterleavedCoder(code *Code, depth int) (*InterleavedCoder, error) {
+	if depth < 1 {
+		return nil, ErrInvalidDepth
+	}
+
+	return &InterleavedCoder{
+		code:    code,
+		depth:   depth,
+		session: code.NewSession(),
+	}, nil
+}
+
+func (ic *InterleavedCoder) Depth() int {
+	return ic.depth
+}
+
+// Len returns the length of interleaved codewords, n*depth.
+func (ic *InterleavedCoder) Len() int {
+	return ic.code.N() * ic.depth
+}
+
+// Encode encodes depth messages and interleaves their codewords: position i*depth + j holds
+// the i'th symbol (in EvaluationPoints(n) order) of the j'th message's codeword.
+func (ic *InterleavedCoder) Encode(msgs [][]uint64) ([]uint64, error) {
+	if len(msgs) != ic.depth {
+		return nil, ErrMessageCountMismatch
+	}
+
+	interleaved := make([]uint64, ic.Len())
+
+	for j, msg := range msgs {
+		codeword, err := ic.code.EncodeOrdered(msg)
+		if err != nil {
+			return nil, fmt.Errorf("message %d: %w", j, err)
+		}
+
+		for i, y := range codeword {
+			interleaved[i*ic.depth+j] = y
+		}
+	}
+
+	return interleaved, nil
+}
+
+// Decode de-interleaves the depth codewords and decodes each of them independently, returning their messages.
+// present[i] == false marks the i'th symbol as erased; a nil present means no erasures.
+// Fails on the first codeword that doesn't decode.
+func (ic *InterleavedCoder) Decode(interleaved []uint64, present []bool) ([][]uint64, error) {
+	if len(interleaved) != ic.Len() || (present != nil && len(present) != ic.Len()) {
+		return nil, ErrCodewordSizeMismatch
+	}
+
+	gao, s := ic.code, ic.session
+	xs := gao.EvaluationMap.EvaluationPoints(gao.N())
+	msgs := make([][]uint64, ic.depth)
+
+	for j := range msgs {
+		var codewordPresent []bool
+		if present != nil {
+			codewordPresent = s.present
+		}
+
+		numMissing := 0
+		for i := range s.ys {
+			pos := i*ic.depth + j
+
+			s.ys[i] = interleaved[pos]
+			if present == nil {
+				continue
+			}
+
+			s.present[i] = present[pos]
+			if !present[pos] {
+				s.ys[i] = 0 // erasures are zero-filled, as in DecodeOrdered.
+				numMissing++
+			}
+		}
+
+		if numMissing > gao.MaxErrors() {
+			return nil, fmt.Errorf("codeword %d: %w", j, ErrTooManyMissingPoints)
+		}
+
+		msg, err := gao.decodeWith(xs, s.ys, codewordPresent, s.interpolate)
+		if err != nil {
+			return nil, fmt.Errorf("codeword %d: %w", j, err)
+		}
+
+		msgs[j] = msg
+	}
+
+	return msgs, nil
+}
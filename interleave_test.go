@@ -0,0 +1,73 @@
+package gao
+
+import (
+	"testing"
+
+	"github.com/jonathanmweiss/go-gao/field"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterleavedCoder(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	testCases := []testCase{
+		{NewSlowEvaluator(f), 18, 5},
+		{NewNttEvaluator(f), 16, 4},
+	}
+
+	const depth = 4
+
+	for _, tc := range testCases {
+		prms, err := NewCodeParameters(tc.EvaluationMap, tc.n, tc.k)
+		a.NoError(err)
+
+		ic, err := NewInterleavedCoder(NewCodeGao(prms), depth)
+		a.NoError(err)
+
+		msgs := make([][]uint64, depth)
+		for j := range msgs {
+			msgs[j] = makeTestSlice(tc.k)
+			msgs[j][0] = uint64(j + 1) // distinct messages.
+		}
+
+		interleaved, err := ic.Encode(msgs)
+		a.NoError(err)
+		a.Len(interleaved, tc.n*depth)
+
+		// a burst of depth*MaxErrors consecutive symbols: MaxErrors errors per codeword.
+		burst := depth * prms.MaxErrors()
+		for pos := 3; pos < 3+burst; pos++ {
+			interleaved[pos] = f.Add(interleaved[pos], 1)
+		}
+
+		decoded, err := ic.Decode(interleaved, nil)
+		a.NoError(err)
+		a.Equal(msgs, decoded)
+
+		// the same burst as erasures.
+		present := make([]bool, len(interleaved))
+		for pos := range present {
+			present[pos] = pos < 3 || pos >= 3+burst
+		}
+
+		decoded, err = ic.Decode(interleaved, present)
+		a.NoError(err)
+		a.Equal(msgs, decoded)
+
+		// one more erasure is too many for some codeword.
+		present[3+burst] = false
+		_, err = ic.Decode(interleaved, present)
+		a.ErrorIs(err, ErrTooManyMissingPoints)
+
+		_, err = ic.Decode(interleaved[1:], nil)
+		a.ErrorIs(err, ErrCodewordSizeMismatch)
+
+		_, err = ic.Encode(msgs[1:])
+		a.ErrorIs(err, ErrMessageCountMismatch)
+	}
+
+	_, err = NewInterleavedCoder(nil, 0)
+	a.ErrorIs(err, ErrInvalidDepth)
+}
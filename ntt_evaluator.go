@@ -1,6 +1,10 @@
 package gao
 
 import (
+	"errors"
+	"fmt"
+	"math/bits"
+
 	"github.com/jonathanmweiss/go-gao/field"
 )
 
@@ -23,19 +27,29 @@ func (e *NttEvaluator) EvaluationPoints(n int) []uint64 {
 		return points
 	}
 
-	// make polynomial p(x) = x.
-	// then attempt to compute its NTT.
-	inner := make([]uint64, n)
-	inner[1] = 1
-	p := field.NewPolynomial(e.pr.GetField(), inner, false)
+	points = rootOfUnityPowers(e.pr.GetField(), n, 1)
 
-	if err := e.pr.NttForward(p); err != nil {
-		panic(err) //. TODO: change API.
-	}
+	e.cache.storePoints(n, points)
 
-	points = p.NoCopySlice()
+	return points
+}
 
-	e.cache.storePoints(n, points)
+// rootOfUnityPowers returns start*w^i for i < n, where w is the root of unity of order nextPow2(n).
+// These are the points NttForward evaluates on, in the order of its output, which isn't bit-reversed.
+// Panics if the field has no such root, as no NTT of that size is possible over it.
+func rootOfUnityPowers(f field.Field, n int, start uint64) []uint64 {
+	w := uint64(1)
+	if n > 1 {
+		var err error
+		if w, err = f.GetRootOfUnity(uint64(1) << bits.Len(uint(n-1))); err != nil {
+			panic(err)
+		}
+	}
+
+	points := make([]uint64, n)
+	for i, x := 0, start; i < n; i, x = i+1, f.Mul(x, w) {
+		points[i] = x
+	}
 
 	return points
 }
@@ -44,7 +58,18 @@ func (e *NttEvaluator) PrimeField() field.Field {
 	return e.pr.GetField()
 }
 
+func (e *NttEvaluator) polyRing() field.PolyRing {
+	return e.pr
+}
+
+// EvaluatePolynomial evaluates p over EvaluationPoints(len(p)), the first len(p) roots of unity of order
+// nextPow2(len(p)). A coefficient-form p of a power-of-two length is transformed in place, otherwise
+// PolyRing.EvaluateDomain evaluates it with a padded transform, truncated to len(p) values.
 func (e *NttEvaluator) EvaluatePolynomial(p *field.Polynomial) ([]uint64, error) {
+	if n := len(p.NoCopySlice()); !p.IsNTT() && !field.IsPowerOfTwo(uint64(n)) {
+		return e.pr.EvaluateDomain(p, e.EvaluationPoints(n))
+	}
+
 	if err := e.pr.NttForward(p); err != nil {
 		return nil, err
 	}
@@ -53,6 +78,11 @@ func (e *NttEvaluator) EvaluatePolynomial(p *field.Polynomial) ([]uint64, error)
 }
 
 func (e *NttEvaluator) GenerateLocatorPolynomial(n int) *field.Polynomial {
+	// the first n roots of unity of a larger order aren't a subgroup, thus have no sparse locator.
+	if !field.IsPowerOfTwo(uint64(n)) {
+		return field.VanishingPolynomial(e.pr, e.EvaluationPoints(n))
+	}
+
 	// The locator polynomial L(x) = (x - x_1)(x - x_2)...(x - x_n)
 	// where x_1, x_2, ..., x_n are the evaluation points
 	// is vanishing for the roots of unity: L(x)=1*x^n-1
@@ -63,7 +93,235 @@ func (e *NttEvaluator) GenerateLocatorPolynomial(n int) *field.Polynomial {
 	return field.NewPolynomial(f, inner, false)
 }
 
+// sparseLocatorPolynomial returns 1 - x^n, see GenerateLocatorPolynomial, or nil if n isn't a power of two.
+func (e *NttEvaluator) sparseLocatorPolynomial(n int) *field.SparsePolynomial {
+	if !field.IsPowerOfTwo(uint64(n)) {
+		return nil
+	}
+
+	f := e.pr.GetField()
+
+	return field.NewSparsePolynomial(f, []field.SparseTerm{{Degree: 0, Coeff: 1}, {Degree: n, Coeff: f.Neg(1)}})
+}
+
+// Domain returns the roots of unity of order n and x^n - 1, the negated GenerateLocatorPolynomial.
+// If n isn't a power of two, it returns the first n roots of unity of order nextPow2(n) and their product locator.
+// It returns an error if the field has no roots of unity of order nextPow2(n).
+func (e *NttEvaluator) Domain(n int) ([]uint64, *field.Polynomial, error) {
+	return e.cache.domain(n, func(n int) ([]uint64, *field.Polynomial, error) {
+		if err := validateNttDomain(e.pr.GetField(), n); err != nil {
+			return nil, nil, err
+		}
+
+		if !field.IsPowerOfTwo(uint64(n)) {
+			return e.EvaluationPoints(n), e.GenerateLocatorPolynomial(n), nil
+		}
+
+		f := e.pr.GetField()
+		locator := field.NewSparsePolynomial(f, []field.SparseTerm{{Degree: 0, Coeff: f.Neg(1)}, {Degree: n, Coeff: 1}})
+
+		return e.EvaluationPoints(n), locator.ToDense(), nil
+	})
+}
+
+// validateNttDomain returns why the first n roots of unity of order nextPow2(n) can't be a domain over f, if at all.
+func validateNttDomain(f field.Field, n int) error {
+	if n < 1 {
+		return ErrDomainTooSmall
+	}
+
+	if n == 1 {
+		return nil
+	}
+
+	m := uint64(1) << bits.Len(uint(n-1))
+	if _, err := f.GetRootOfUnity(m); err != nil {
+		return fmt.Errorf("%w, nextPow2(%d) = %d: %w", ErrNoRootOfUnity, n, m, err)
+	}
+
+	return nil
+}
+
+/*
+ValidateNttCode is a preflight check that a Code over NewNttEvaluator(f) with parameters (n, k) can encode
+and decode. It runs the checks of NewCodeParameters, and describes the parameters in its error:
+  - n >= k, thus the stop degree (n+k)/2 lies in [k, n] and MaxErrors is non-negative.
+  - f has n distinct non-zero points, and a primitive root of unity w of order nextPow2(n).
+
+The locator needs no check of its own: for a power of two n, w^n = 1, thus x^n - 1 vanishes on every w^i,
+and for other lengths it is built as the product of x - w^i.
+*/
+func ValidateNttCode(f field.Field, n, k int) error {
+	if _, err := NewCodeParameters(NewNttEvaluator(f), n, k); err != nil {
+		return fmt.Errorf("NTT code n=%d, k=%d over a field of order %d: %w", n, k, f.Modulus(), err)
+	}
+
+	return nil
+}
+
 // does not support fast Gao.
 func (e *NttEvaluator) isNTT() bool {
 	return true
 }
+
+var ErrZeroCosetShift = errors.New("coset shift must be non-zero")
+var ErrCosetShiftInSubgroup = errors.New("coset shift is in the subgroup of roots of unity it shifts")
+
+// CosetNttEvaluator evaluates over the coset g*w^i of the roots of unity w^i, for a shift g, using NTTs:
+// p(g*x) has the coefficients p_i*g^i, so it pre-scales before the forward transform, and post-scales by g^-i
+// after the backward one. Codes of length n over different cosets of the roots of unity of order nextPow2(n)
+// have disjoint evaluation points.
+type CosetNttEvaluator struct {
+	cache *evaluationCache
+
+	pr       field.PolyRing
+	shift    uint64
+	shiftInv uint64
+}
+
+// NewCosetNttEvaluator returns an evaluator over the coset shift*w^i.
+// The field's generator isn't in any subgroup of order n < p-1, thus it shifts the roots of unity to a disjoint coset.
+// A shift in the subgroup of order nextPow2(n) would only permute it: NewCodeParameters and Domain reject it
+// with ErrCosetShiftInSubgroup.
+func NewCosetNttEvaluator(f field.Field, shift uint64) (*CosetNttEvaluator, error) {
+	shift = f.Reduce(shift)
+	if shift == 0 {
+		return nil, ErrZeroCosetShift
+	}
+
+	return &CosetNttEvaluator{
+		pr:       field.NewDensePolyRing(f),
+		cache:    newEvaluatorCache(),
+		shift:    shift,
+		shiftInv: f.Inverse(shift),
+	}, nil
+}
+
+// Shift returns the coset's shift g.
+func (e *CosetNttEvaluator) Shift() uint64 {
+	return e.shift
+}
+
+func (e *CosetNttEvaluator) EvaluationPoints(n int) []uint64 {
+	points := e.cache.loadPoints(n)
+	if points != nil {
+		return points
+	}
+
+	// the roots of unity, in the order of the NTT's output, shifted by g.
+	points = rootOfUnityPowers(e.pr.GetField(), n, e.shift)
+
+	e.cache.storePoints(n, points)
+
+	return points
+}
+
+func (e *CosetNttEvaluator) PrimeField() field.Field {
+	return e.pr.GetField()
+}
+
+func (e *CosetNttEvaluator) polyRing() field.PolyRing {
+	return e.pr
+}
+
+// EvaluatePolynomial evaluates p over EvaluationPoints(len(p)), on the coset of the roots of unity of order
+// nextPow2(len(p)). As in NttEvaluator, a p of a power-of-two length is scaled and transformed in place,
+// otherwise PolyRing.EvaluateDomain evaluates it.
+func (e *CosetNttEvaluator) EvaluatePolynomial(p *field.Polynomial) ([]uint64, error) {
+	if p.IsNTT() {
+		return nil, errNotInCoefficientForm
+	}
+
+	if n := len(p.NoCopySlice()); !field.IsPowerOfTwo(uint64(n)) {
+		return e.pr.EvaluateDomain(p, e.EvaluationPoints(n))
+	}
+
+	e.scaleByPowers(p.NoCopySlice(), e.shift)
+
+	if err := e.pr.NttForward(p); err != nil {
+		return nil, err
+	}
+
+	return p.NoCopySlice(), nil
+}
+
+// interpolate returns the polynomial whose evaluations over EvaluationPoints(len(ys)) are ys.
+// ys is transformed in place.
+func (e *CosetNttEvaluator) interpolate(ys []uint64) (*field.Polynomial, error) {
+	g1 := field.NewPolynomial(e.pr.GetField(), ys, true)
+	if err := g1.ValidateNttLength(); err != nil {
+		return nil, err
+	}
+
+	if err := e.pr.NttBackward(g1); err != nil {
+		return nil, err
+	}
+
+	e.scaleByPowers(g1.NoCopySlice(), e.shiftInv)
+
+	return g1, nil
+}
+
+// scaleByPowers sets coeffs[i] *= c^i.
+func (e *CosetNttEvaluator) scaleByPowers(coeffs []uint64, c uint64) {
+	f := e.pr.GetField()
+
+	pow := uint64(1)
+	for i := range coeffs {
+		coeffs[i] = f.Mul(coeffs[i], pow)
+		pow = f.Mul(pow, c)
+	}
+}
+
+// GenerateLocatorPolynomial returns x^n - g^n, which vanishes on g*w for every root of unity w of order n.
+// If n isn't a power of two, it returns the product locator of EvaluationPoints(n).
+func (e *CosetNttEvaluator) GenerateLocatorPolynomial(n int) *field.Polynomial {
+	if sl := e.sparseLocatorPolynomial(n); sl != nil {
+		return sl.ToDense()
+	}
+
+	return field.VanishingPolynomial(e.pr, e.EvaluationPoints(n))
+}
+
+// sparseLocatorPolynomial returns x^n - g^n, see GenerateLocatorPolynomial, or nil if n isn't a power of two.
+func (e *CosetNttEvaluator) sparseLocatorPolynomial(n int) *field.SparsePolynomial {
+	if !field.IsPowerOfTwo(uint64(n)) {
+		return nil
+	}
+
+	f := e.pr.GetField()
+
+	return field.NewSparsePolynomial(f, []field.SparseTerm{
+		{Degree: 0, Coeff: f.Neg(f.Pow(e.shift, uint64(n)))},
+		{Degree: n, Coeff: 1},
+	})
+}
+
+// Domain returns the coset of order n and x^n - g^n, see NttEvaluator.Domain.
+func (e *CosetNttEvaluator) Domain(n int) ([]uint64, *field.Polynomial, error) {
+	return e.cache.domain(n, func(n int) ([]uint64, *field.Polynomial, error) {
+		if err := validateNttDomain(e.pr.GetField(), n); err != nil {
+			return nil, nil, err
+		}
+
+		if err := e.validateDomain(n); err != nil {
+			return nil, nil, err
+		}
+
+		return e.EvaluationPoints(n), e.GenerateLocatorPolynomial(n), nil
+	})
+}
+
+// validateDomain rejects a shift in the subgroup of order nextPow2(n), see NewCosetNttEvaluator.
+func (e *CosetNttEvaluator) validateDomain(n int) error {
+	if n > 0 && e.pr.GetField().Pow(e.shift, uint64(1)<<bits.Len(uint(n-1))) == 1 {
+		return ErrCosetShiftInSubgroup
+	}
+
+	return nil
+}
+
+// supports fast Gao, interpolating via interpolatingMap.
+func (e *CosetNttEvaluator) isNTT() bool {
+	return true
+}
@@ -0,0 +1,135 @@
+package gao
+
+import (
+	"github.com/jonathanmweiss/go-gao/field"
+)
+
+// maxPGZRedundancy is the largest n-k for which the decoder solves for the error locator directly,
+// see decodePGZ, instead of running the partial EEA. It corrects up to 2 errors.
+const maxPGZRedundancy = 4
+
+// usesPGZ reports whether decoding takes the decodePGZ fast path: n-k is small, and the decoding radius
+// is the default one, since SetStopDegree only applies to the partial EEA.
+func (gao *Code) usesPGZ() bool {
+	redundancy := gao.N() - gao.K()
+
+	return redundancy >= 2 && redundancy <= maxPGZRedundancy && gao.stopDegree == (gao.N()+gao.K())/2
+}
+
+/*
+decodePGZ decodes the interpolant g1 of a received word over xs = EvaluationPoints(n) the
+Peterson-Gorenstein-Zierler way, for codes with n-k <= maxPGZRedundancy, thus at most 2 errors.
+
+The syndromes S_j = \sum_i y_i x_i^j / g0'(x_i), for j < n-k, are the coefficients of the expansion
+g1/g0 = \sum_j S_j x^(-j-1), thus they are computed from the n-k top coefficients of g1 and g0.
+Errors e_l at the points X_l add E_l = e_l / g0'(X_l) to them: S_j = \sum_l E_l X_l^j. Hence the error locator
+\prod (x - X_l) = x^2 + s1*x + s2 satisfies S_(j+2) + s1*S_(j+1) + s2*S_j = 0, solved by Cramer's rule for
+two errors, while a single error is at X = S_1/S_0.
+The message is g1 minus the interpolant of the errors, \sum_l E_l * g0/(x - X_l).
+
+It returns the message f and the error locator v, or ok = false if no codeword is within MaxErrors of
+the received word, e.g., when the roots of the error locator aren't evaluation points.
+*/
+func (gao *Code) decodePGZ(xs []uint64, g1 *field.Polynomial) (f, v *field.Polynomial, ok bool) {
+	fld := gao.PrimeField()
+	n, redundancy := gao.N(), gao.N()-gao.K()
+
+	g0, b := gao.locator().NoCopySlice(), g1.NoCopySlice()
+
+	// S_j = (b_(n-1-j) - \sum_(l=1..j) a_(n-l) S_(j-l)) / a_n, where g0 = \sum a_m x^m and g1 = \sum b_m x^m.
+	leadInv := fld.Inverse(g0[n])
+	syndromes := make([]uint64, redundancy)
+
+	for j := range syndromes {
+		acc := uint64(0)
+		if n-1-j < len(b) {
+			acc = b[n-1-j]
+		}
+
+		for l := 1; l <= j; l++ {
+			acc = fld.Sub(acc, fld.Mul(g0[n-l], syndromes[j-l]))
+		}
+
+		syndromes[j] = fld.Mul(acc, leadInv)
+	}
+
+	locs, errVals, ok := gao.solvePGZ(xs, syndromes)
+	if !ok {
+		return nil, nil, false
+	}
+
+	// the corrected word must be a codeword: all of its syndromes vanish.
+	for j, s := range syndromes {
+		for l, x := range locs {
+			s = fld.Sub(s, fld.Mul(errVals[l], fld.Pow(x, uint64(j))))
+		}
+
+		if s != 0 {
+			return nil, nil, false
+		}
+	}
+
+	fCoeffs := make([]uint64, n)
+	copy(fCoeffs, b)
+
+	// g0/(x - X) by synthetic division: q_(n-1) = a_n, and q_(m-1) = a_m + X*q_m.
+	for l, x := range locs {
+		q := uint64(0)
+		for m := n; m > 0; m-- {
+			q = fld.Add(g0[m], fld.Mul(x, q))
+			fCoeffs[m-1] = fld.Sub(fCoeffs[m-1], fld.Mul(errVals[l], q))
+		}
+	}
+
+	f = field.NewPolynomial(fld, fCoeffs, false)
+
+	return f.Trim(), field.PolyProductMonicNegRoots(fld, locs), true
+}
+
+// solvePGZ returns the error points X_l among xs and their values E_l, given the syndromes S_j = \sum_l E_l X_l^j.
+// It tries two errors first, since a single error (or none) leaves the 2x2 system singular.
+func (gao *Code) solvePGZ(xs, syndromes []uint64) (locs, errVals []uint64, ok bool) {
+	fld := gao.PrimeField()
+	s := syndromes
+
+	if gao.MaxErrors() >= 2 {
+		// [S1 S0; S2 S1] (s1, s2) = -(S2, S3).
+		det := fld.Sub(fld.Mul(s[1], s[1]), fld.Mul(s[0], s[2]))
+
+		if det != 0 {
+			detInv := fld.Inverse(det)
+			s1 := fld.Mul(fld.Sub(fld.Mul(s[0], s[3]), fld.Mul(s[1], s[2])), detInv)
+			s2 := fld.Mul(fld.Sub(fld.Mul(s[2], s[2]), fld.Mul(s[1], s[3])), detInv)
+
+			for _, x := range xs {
+				if fld.Add(fld.Mul(fld.Add(x, s1), x), s2) == 0 {
+					locs = append(locs, x)
+				}
+			}
+
+			if len(locs) != 2 {
+				return nil, nil, false
+			}
+
+			// E_1 + E_2 = S_0 and E_1 X_1 + E_2 X_2 = S_1.
+			x1, x2 := locs[0], locs[1]
+			e1 := fld.Mul(fld.Sub(s[1], fld.Mul(s[0], x2)), fld.Inverse(fld.Sub(x1, x2)))
+
+			return locs, []uint64{e1, fld.Sub(s[0], e1)}, true
+		}
+	}
+
+	if s[0] == 0 {
+		// no errors, unless the syndromes are inconsistent, which the caller checks.
+		return nil, nil, true
+	}
+
+	x := fld.Mul(s[1], fld.Inverse(s[0]))
+	for _, xi := range xs {
+		if xi == x {
+			return []uint64{x}, []uint64{s[0]}, true
+		}
+	}
+
+	return nil, nil, false
+}
@@ -0,0 +1,192 @@
+package gao
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/jonathanmweiss/go-gao/field"
+	"github.com/stretchr/testify/assert"
+)
+
+// decodeEEA decodes received like Decode, always running the partial EEA.
+func decodeEEA(gao *Code, received map[uint64]uint64) ([]uint64, error) {
+	xs, ys, _, err := gao.prepareDecoding(received)
+	if err != nil {
+		return nil, err
+	}
+
+	g1, err := gao.interpolate(xs, ys)
+	if err != nil {
+		return nil, err
+	}
+
+	f, r, _, err := gao.decodeGeneric(context.Background(), g1)
+	if err != nil {
+		return nil, err
+	}
+
+	if !r.IsZero() || f.Degree() > gao.K() {
+		return nil, ErrDecoding
+	}
+
+	return f.Trim().ToSlice(), nil
+}
+
+func TestDecodePGZ(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	bf, err := field.NewBinaryField(8)
+	a.NoError(err)
+
+	coset, err := NewCosetNttEvaluator(f, f.Generator())
+	a.NoError(err)
+
+	testCases := []testCase{
+		{NewSlowEvaluator(f), 18, 14},
+		{NewSlowEvaluator(f), 17, 14},
+		{NewSlowEvaluator(f), 12, 10},
+		{NewNttEvaluator(f), 16, 12},
+		{NewNttEvaluator(f), 64, 61},
+		{coset, 32, 28},
+		{NewSlowEvaluator(bf), 20, 16},
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+
+	for _, tc := range testCases {
+		prms, err := NewCodeParameters(tc.EvaluationMap, tc.n, tc.k)
+		a.NoError(err)
+
+		gao := NewCodeGao(prms)
+		a.True(gao.usesPGZ())
+
+		fld := prms.PrimeField()
+		xs := prms.EvaluationPoints(tc.n)
+
+		data := make([]uint64, tc.k)
+		for i := range data {
+			data[i] = rnd.Uint64() % fld.Modulus()
+		}
+
+		encoded, err := gao.Encode(data)
+		a.NoError(err)
+
+		for iter := 0; iter < 100; iter++ {
+			received := make(map[uint64]uint64, tc.n)
+			for x, y := range encoded {
+				received[x] = y
+			}
+
+			// up to MaxErrors()+1 corrupted symbols, some of them erased.
+			numErrors := rnd.Intn(prms.MaxErrors() + 2)
+			for i, j := range rnd.Perm(tc.n)[:numErrors] {
+				if i%2 == 1 {
+					delete(received, xs[j])
+					continue
+				}
+
+				received[xs[j]] = fld.Add(received[xs[j]], rnd.Uint64()%(fld.Modulus()-1)+1)
+			}
+
+			expected, expectedErr := decodeEEA(gao, received)
+
+			decoded, err := gao.Decode(received)
+			a.Equal(expected, decoded, "%T n=%d k=%d errors=%d", tc.EvaluationMap, tc.n, tc.k, numErrors)
+			a.Equal(expectedErr, err)
+
+			if numErrors <= prms.MaxErrors() {
+				a.NoError(err)
+				a.Equal(numErrors, gao.LastDecodeErrors())
+
+				// decoded by decodePGZ rather than the EEA fallback.
+				xs, ys, _, err := gao.prepareDecoding(received)
+				a.NoError(err)
+
+				g1, err := gao.interpolate(xs, ys)
+				a.NoError(err)
+
+				_, v, ok := gao.decodePGZ(xs, g1)
+				a.True(ok)
+				a.LessOrEqual(v.Degree(), numErrors)
+			}
+		}
+	}
+
+	// a larger n-k, or a non-default stop degree, uses the EEA.
+	prms, err := NewCodeParameters(NewSlowEvaluator(f), 18, 13)
+	a.NoError(err)
+	a.False(NewCodeGao(prms).usesPGZ())
+
+	prms, err = NewCodeParameters(NewSlowEvaluator(f), 18, 14)
+	a.NoError(err)
+
+	gao := NewCodeGao(prms)
+	a.NoError(gao.SetStopDegree(17))
+	a.False(gao.usesPGZ())
+}
+
+/*
+BenchmarkDecodePGZ/eval=slow/pgz         	   21813	     57773 ns/op
+BenchmarkDecodePGZ/eval=slow/eea         	     277	   3724878 ns/op
+BenchmarkDecodePGZ/eval=ntt/pgz          	   28615	     41534 ns/op
+BenchmarkDecodePGZ/eval=ntt/eea          	     412	   2637410 ns/op
+*/
+func BenchmarkDecodePGZ(b *testing.B) {
+	f, err := field.NewPrimeField(65537)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	const n, k = 1 << 10, 1<<10 - 4
+
+	for _, ev := range []struct {
+		name string
+		eval EvaluationMap
+	}{
+		{"slow", NewSlowEvaluator(f)},
+		{"ntt", NewNttEvaluator(f)},
+	} {
+		prms, err := NewCodeParameters(ev.eval, n, k)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		gao := NewCodeGao(prms)
+
+		codeword, err := gao.EncodeOrdered(makeTestSlice(k))
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		codeword[0] = f.Add(codeword[0], 1)
+		codeword[n/2] = f.Add(codeword[n/2], 7)
+
+		// both decoders start from the same interpolant.
+		xs := prms.EvaluationPoints(n)
+
+		g1, err := gao.interpolate(xs, codeword)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(fmt.Sprintf("eval=%s/pgz", ev.name), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, _, ok := gao.decodePGZ(xs, g1); !ok {
+					b.Fatal("decodePGZ failed")
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("eval=%s/eea", ev.name), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, _, _, err := gao.decodeGeneric(context.Background(), g1); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package gao
+
+import (
+	"github.com/jonathanmweiss/go-gao/field"
+)
+
+// DecoderSession decodes many words of a Code, reusing its buffers across calls, see Code.NewSession.
+// Sessions are not safe for concurrent use; use one session per goroutine.
+type DecoderSession struct {
+	code *Code
+
+	ys      []uint64
+	present []bool
+	scratch field.InterpScratch
+}
+
+// NewSession returns a DecoderSession of the code.
+func (gao *Code) NewSession() *DecoderSession {
+	return &DecoderSession{
+		code:    gao,
+		ys:      make([]uint64, gao.N()),
+		present: make([]bool, gao.N()),
+	}
+}
+
+// Decode decodes received like Code.Decode, but allocates less: the received values and the interpolation
+// buffers are reused across calls. The partial EEA still allocates, thus the savings are mostly for codes with a SlowEvaluator.
+func (s *DecoderSession) Decode(received map[uint64]uint64) ([]uint64, error) {
+	gao := s.code
+	if len(received) > gao.N() {
+		return nil, ErrTooManyPoints
+	}
+
+	xs := gao.EvaluationMap.EvaluationPoints(gao.N())
+	if numMissing := fillReceived(received, xs, s.ys, s.present); numMissing > gao.MaxErrors() {
+		return nil, ErrTooManyMissingPoints
+	}
+
+	return gao.decodeWith(xs, s.ys, s.present, s.interpolate)
+}
+
+func (s *DecoderSession) interpolate(xs, ys []uint64) (*field.Polynomial, error) {
+	if s.code.nttDomain() {
+		// transforms ys in place, which the next Decode overwrites anyway.
+		return s.code.interpolate(xs, ys)
+	}
+
+	interp, err := s.code.domainInterpolator()
+	if err != nil {
+		return nil, err
+	}
+
+	return interp.InterpolateInto(ys, &s.scratch)
+}
@@ -0,0 +1,129 @@
+package gao
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/jonathanmweiss/go-gao/field"
+	"github.com/jonathanmweiss/go-gao/gaotest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecoderSession(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	testCases := []testCase{
+		{NewSlowEvaluator(f), 18, 5},
+		{NewNttEvaluator(f), 16, 4},
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+
+	for _, tc := range testCases {
+		prms, err := NewCodeParameters(tc.EvaluationMap, tc.n, tc.k)
+		a.NoError(err)
+
+		gao := NewCodeGao(prms)
+		session := gao.NewSession()
+
+		for i := 0; i < 30; i++ {
+			data := make([]uint64, tc.k)
+			for j := range data {
+				data[j] = rnd.Uint64() % f.Modulus()
+			}
+
+			encoded, err := gao.Encode(data)
+			a.NoError(err)
+
+			// clean, erased, corrupted and undecodable words.
+			received := copyPoints(encoded)
+			switch i % 4 {
+			case 1:
+				gaotest.EraseN(received, prms.MaxErrors(), rnd)
+			case 2:
+				gaotest.CorruptN(f, received, prms.MaxErrors(), rnd)
+			case 3:
+				gaotest.CorruptN(f, received, prms.N()-prms.K(), rnd)
+			}
+
+			before := copyPoints(received)
+
+			got, sessionErr := session.Decode(received)
+			a.Equal(before, received) // not modified.
+
+			expected, err := gao.Decode(received)
+			a.Equal(err, sessionErr)
+			a.Equal(expected, got)
+		}
+
+		received, err := gao.Encode(makeTestSlice(tc.k))
+		a.NoError(err)
+
+		gaotest.EraseN(received, prms.MaxErrors()+1, rnd)
+
+		_, err = session.Decode(received)
+		a.ErrorIs(err, ErrTooManyMissingPoints)
+	}
+}
+
+/*
+The NTT path interpolates in place either way; its remaining allocations are the EEA's.
+
+BenchmarkDecoderSession/slow/n=64/Code.Decode         	    2716	    405435 ns/op	  166880 B/op	     830 allocs/op
+BenchmarkDecoderSession/slow/n=64/DecoderSession      	    4524	    225477 ns/op	   39681 B/op	     242 allocs/op
+BenchmarkDecoderSession/ntt/n=64/Code.Decode          	   10000	    105853 ns/op	   39116 B/op	     240 allocs/op
+BenchmarkDecoderSession/ntt/n=64/DecoderSession       	    9463	    115674 ns/op	   38604 B/op	     239 allocs/op
+*/
+func BenchmarkDecoderSession(b *testing.B) {
+	f, err := field.NewPrimeField(65537)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for _, ev := range []struct {
+		name string
+		eval EvaluationMap
+	}{
+		{"slow", NewSlowEvaluator(f)},
+		{"ntt", NewNttEvaluator(f)},
+	} {
+		n, k := 64, 16
+
+		prms, err := NewCodeParameters(ev.eval, n, k)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		gao := NewCodeGao(prms)
+
+		encoded, err := gao.Encode(makeTestSlice(k))
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		gaotest.CorruptN(f, encoded, prms.MaxErrors()/2, rand.New(rand.NewSource(1)))
+
+		b.Run(fmt.Sprintf("%s/n=%d/Code.Decode", ev.name, n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := gao.Decode(encoded); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("%s/n=%d/DecoderSession", ev.name, n), func(b *testing.B) {
+			session := gao.NewSession()
+
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := session.Decode(encoded); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package gao
+
+import (
+	"time"
+
+	"github.com/jonathanmweiss/go-gao/field"
+)
+
+// Tracer receives the stages of a Code's decoder, e.g., to measure where time goes.
+// Decodes that skip a stage (e.g., uncorrupted codewords skip the EEA) don't report it.
+// Calls are synchronous, from the decoding goroutine.
+type Tracer interface {
+	// OnInterpolate reports the interpolation of the received word into g1.
+	OnInterpolate(elapsed time.Duration, g1Degree int)
+	// OnEEA reports the partial extended Euclidean algorithm on g0 and g1, stopping on g = u*g0 + v*g1.
+	OnEEA(elapsed time.Duration, gDegree, vDegree int)
+	// OnDivision reports the division g = f*v + r.
+	OnDivision(elapsed time.Duration, fDegree int, remainderIsZero bool)
+}
+
+// SetTracer sets the Tracer of gao's decodes, nil removes it. Without a tracer, decoding doesn't measure time.
+// Not safe to call concurrently with decoding.
+func (gao *Code) SetTracer(t Tracer) {
+	gao.tracer = t
+}
+
+// traceStart returns the start time of a traced stage, or the zero time without a tracer.
+func (gao *Code) traceStart() time.Time {
+	if gao.tracer == nil {
+		return time.Time{}
+	}
+
+	return time.Now()
+}
+
+func (gao *Code) traceEEA(start time.Time, g, v *field.Polynomial) {
+	if gao.tracer != nil {
+		gao.tracer.OnEEA(time.Since(start), g.Degree(), v.Degree())
+	}
+}
+
+func (gao *Code) traceDivision(start time.Time, f, r *field.Polynomial) {
+	if gao.tracer != nil {
+		gao.tracer.OnDivision(time.Since(start), f.Degree(), r.IsZero())
+	}
+}
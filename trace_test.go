@@ -0,0 +1,79 @@
+package gao
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jonathanmweiss/go-gao/field"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingTracer struct {
+	calls []string
+
+	g1Degree, vDegree int
+	remainderIsZero   bool
+}
+
+func (r *recordingTracer) OnInterpolate(_ time.Duration, g1Degree int) {
+	r.calls = append(r.calls, "interpolate")
+	r.g1Degree = g1Degree
+}
+
+func (r *recordingTracer) OnEEA(_ time.Duration, _, vDegree int) {
+	r.calls = append(r.calls, "eea")
+	r.vDegree = vDegree
+}
+
+func (r *recordingTracer) OnDivision(_ time.Duration, _ int, remainderIsZero bool) {
+	r.calls = append(r.calls, "division")
+	r.remainderIsZero = remainderIsZero
+}
+
+func TestTracer(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	testCases := []testCase{
+		{NewSlowEvaluator(f), 18, 5},
+		{NewNttEvaluator(f), 16, 4},
+	}
+
+	for _, tc := range testCases {
+		prms, err := NewCodeParameters(tc.EvaluationMap, tc.n, tc.k)
+		a.NoError(err)
+
+		gao := NewCodeGao(prms)
+
+		tracer := &recordingTracer{}
+		gao.SetTracer(tracer)
+
+		codeword, err := gao.EncodeOrdered(makeTestSlice(tc.k))
+		a.NoError(err)
+
+		// uncorrupted codewords skip the EEA.
+		_, err = gao.DecodeOrdered(codeword, nil)
+		a.NoError(err)
+		a.Equal([]string{"interpolate"}, tracer.calls)
+		a.Equal(tc.k-1, tracer.g1Degree)
+
+		tracer.calls = nil
+		codeword[2] = f.Add(codeword[2], 1)
+		codeword[5] = f.Add(codeword[5], 1)
+
+		_, err = gao.DecodeOrdered(codeword, nil)
+		a.NoError(err)
+		a.Equal([]string{"interpolate", "eea", "division"}, tracer.calls)
+		a.Equal(2, tracer.vDegree)
+		a.True(tracer.remainderIsZero)
+
+		// removed.
+		tracer.calls = nil
+		gao.SetTracer(nil)
+
+		_, err = gao.DecodeOrdered(codeword, nil)
+		a.NoError(err)
+		a.Empty(tracer.calls)
+	}
+}
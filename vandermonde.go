@@ -0,0 +1,227 @@
+package gao
+
+import (
+	"container/list"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/jonathanmweiss/go-gao/field"
+)
+
+/*
+VandermondeCoder encodes by multiplying the message with the n×k Vandermonde matrix
+V[i][j] = x_i^j over the evaluation points, and decodes erasures by solving the k×k linear system
+of the first k present points. Meant for small parameters, where it is simpler to audit than the
+polynomial machinery.
+
+It corrects up to n-k erasures, but only detects errors: a decoded message that disagrees with any
+present point results in ErrDecoding.
+*/
+type VandermondeCoder struct {
+	CodeParams
+
+	xs          []uint64
+	vandermonde [][]uint64 // n×k
+
+	mu sync.Mutex
+	// inverses of the k×k submatrices of vandermonde, keyed by their row indices. Each erasure pattern
+	// has its own, thus only the maxCachedInverses most recently used ones are kept, in lru.
+	inverses map[string]*list.Element
+	lru      *list.List
+}
+
+// maxCachedInverses bounds the inverses a VandermondeCoder caches, each of k×k elements.
+const maxCachedInverses = 64
+
+type cachedInverse struct {
+	key     string
+	inverse [][]uint64
+}
+
+func NewVandermondeCoder(c CodeParams) *VandermondeCoder {
+	f := c.PrimeField()
+	xs := c.EvaluationPoints(c.N())
+
+	vandermonde := make([][]uint64, c.N())
+	for i, x := range xs {
+		row := make([]uint64, c.K())
+
+		xPow := uint64(1)
+		for j := range row {
+			row[j] = xPow
+			xPow = f.Mul(xPow, f.Reduce(x))
+		}
+
+		vandermonde[i] = row
+	}
+
+	v := &VandermondeCoder{
+		CodeParams:  c,
+		xs:          xs,
+		vandermonde: vandermonde,
+		inverses:    map[string]*list.Element{},
+		lru:         list.New(),
+	}
+
+	// precompute the inverse used when no points are missing.
+	if c.K() > 0 {
+		rows := make([]int, c.K())
+		for i := range rows {
+			rows[i] = i
+		}
+
+		if _, err := v.inverseOf(rows); err != nil {
+			panic(err) // distinct evaluation points always yield an invertible Vandermonde matrix.
+		}
+	}
+
+	return v
+}
+
+func (v *VandermondeCoder) Encode(data []uint64) (map[uint64]uint64, error) {
+	ys, err := v.EncodeOrdered(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return toPointsMap(v.xs, ys), nil
+}
+
+func (v *VandermondeCoder) EncodeOrdered(data []uint64) ([]uint64, error) {
+	f := v.PrimeField()
+	if err := validateData(f, data, v.K()); err != nil {
+		return nil, err
+	}
+
+	ys := make([]uint64, v.N())
+	for i, row := range v.vandermonde {
+		for j, d := range data {
+			ys[i] = f.Add(ys[i], f.Mul(row[j], d))
+		}
+	}
+
+	return ys, nil
+}
+
+// Decode doesn't modify received.
+func (v *VandermondeCoder) Decode(received map[uint64]uint64) ([]uint64, error) {
+	if len(received) > v.N() {
+		return nil, ErrTooManyPoints
+	}
+
+	codeword := make([]uint64, v.N())
+	present := make([]bool, v.N())
+
+	for i, x := range v.xs {
+		codeword[i], present[i] = received[x]
+	}
+
+	return v.DecodeOrdered(codeword, present)
+}
+
+func (v *VandermondeCoder) DecodeOrdered(codeword []uint64, present []bool) ([]uint64, error) {
+	if len(codeword) != v.N() || (present != nil && len(present) != v.N()) {
+		return nil, ErrCodewordSizeMismatch
+	}
+
+	f := v.PrimeField()
+
+	rows := make([]int, 0, v.K())
+	for i := range codeword {
+		if len(rows) == v.K() {
+			break
+		}
+
+		if present == nil || present[i] {
+			rows = append(rows, i)
+		}
+	}
+
+	if len(rows) < v.K() {
+		return nil, ErrTooManyMissingPoints
+	}
+
+	inv, err := v.inverseOf(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	// m = V_S^{-1} * y_S
+	msg := make([]uint64, v.K())
+	for i, row := range inv {
+		for j, r := range rows {
+			msg[i] = f.Add(msg[i], f.Mul(row[j], f.Reduce(codeword[r])))
+		}
+	}
+
+	// the remaining present points must agree with the message.
+	for i, row := range v.vandermonde {
+		if present != nil && !present[i] {
+			continue
+		}
+
+		y := uint64(0)
+		for j, m := range msg {
+			y = f.Add(y, f.Mul(row[j], m))
+		}
+
+		if !f.Equals(y, codeword[i]) {
+			return nil, ErrDecoding
+		}
+	}
+
+	// trim trailing zeros, as Code.Decode does.
+	last := len(msg) - 1
+	for last > 0 && msg[last] == 0 {
+		last--
+	}
+
+	return msg[:last+1], nil
+}
+
+// inverseOf returns the (cached) inverse of the submatrix of the Vandermonde matrix made of rows,
+// evicting the least recently used inverse past maxCachedInverses.
+func (v *VandermondeCoder) inverseOf(rows []int) ([][]uint64, error) {
+	keyBuilder := strings.Builder{}
+	for _, r := range rows {
+		keyBuilder.WriteString(strconv.Itoa(r))
+		keyBuilder.WriteByte(',')
+	}
+
+	key := keyBuilder.String()
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if elem, ok := v.inverses[key]; ok {
+		v.lru.MoveToFront(elem)
+
+		return elem.Value.(*cachedInverse).inverse, nil
+	}
+
+	sub := make([][]uint64, len(rows))
+	for i, r := range rows {
+		sub[i] = v.vandermonde[r]
+	}
+
+	m, err := field.NewMatrix(v.PrimeField(), sub)
+	if err != nil {
+		return nil, err
+	}
+
+	inv, err := m.Inverse()
+	if err != nil {
+		return nil, err
+	}
+
+	inverse := inv.ToSlices()
+	v.inverses[key] = v.lru.PushFront(&cachedInverse{key: key, inverse: inverse})
+
+	if v.lru.Len() > maxCachedInverses {
+		oldest := v.lru.Remove(v.lru.Back()).(*cachedInverse)
+		delete(v.inverses, oldest.key)
+	}
+
+	return inverse, nil
+}
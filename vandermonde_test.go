@@ -0,0 +1,92 @@
+package gao
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/jonathanmweiss/go-gao/field"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_ Encoder = (*VandermondeCoder)(nil)
+	_ Decoder = (*VandermondeCoder)(nil)
+	_ Encoder = (*Code)(nil)
+	_ Decoder = (*Code)(nil)
+)
+
+func TestVandermondeCoder(t *testing.T) {
+	a := assert.New(t)
+	f, err := field.NewPrimeField(65537)
+	a.NoError(err)
+
+	prms, err := NewCodeParameters(NewSlowEvaluator(f), 18, 5)
+	a.NoError(err)
+
+	gao := NewCodeGao(prms)
+	vc := NewVandermondeCoder(prms)
+
+	expected, err := gao.Encode(makeTestSlice(5))
+	a.NoError(err)
+
+	encoded, err := vc.Encode(makeTestSlice(5))
+	a.NoError(err)
+	a.Equal(expected, encoded)
+
+	decoded, err := vc.Decode(encoded)
+	a.NoError(err)
+	a.Equal(makeTestSlice(5), decoded)
+
+	// up to n-k erasures.
+	for _, x := range shuffle(prms.EvaluationPoints(prms.N()))[:prms.N()-prms.K()] {
+		delete(encoded, x)
+	}
+
+	decoded, err = vc.Decode(encoded)
+	a.NoError(err)
+	a.Equal(makeTestSlice(5), decoded)
+
+	for x := range encoded {
+		delete(encoded, x)
+		break
+	}
+
+	_, err = vc.Decode(encoded)
+	a.ErrorIs(err, ErrTooManyMissingPoints)
+
+	// errors are detected.
+	codeword, err := vc.EncodeOrdered(makeTestSlice(5))
+	a.NoError(err)
+
+	pos := rand.Intn(prms.N())
+	codeword[pos] = f.Add(codeword[pos], 1)
+
+	_, err = vc.DecodeOrdered(codeword, nil)
+	a.ErrorIs(err, ErrDecoding)
+
+	// zero message.
+	codeword, err = vc.EncodeOrdered(nil)
+	a.NoError(err)
+
+	decoded, err = vc.DecodeOrdered(codeword, nil)
+	a.NoError(err)
+	a.Equal([]uint64{0}, decoded)
+
+	// each erasure pattern has its own inverse, of which only maxCachedInverses are kept.
+	codeword, err = vc.EncodeOrdered(makeTestSlice(5))
+	a.NoError(err)
+
+	for i := 0; i < 2*maxCachedInverses; i++ {
+		present := make([]bool, prms.N())
+		for _, pos := range rand.Perm(prms.N())[:prms.K()] {
+			present[pos] = true
+		}
+
+		decoded, err = vc.DecodeOrdered(codeword, present)
+		a.NoError(err)
+		a.Equal(makeTestSlice(5), decoded)
+	}
+
+	a.Equal(maxCachedInverses, vc.lru.Len())
+	a.Len(vc.inverses, maxCachedInverses)
+}